@@ -0,0 +1,91 @@
+package account
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+var (
+	ErrAddressNotFound = errs.NotFound("address not found")
+	ErrInvalidAddress  = errs.InvalidArgument("invalid address")
+)
+
+// Address is a shipping or billing address of an account. An account has at most
+// one default shipping and one default billing address.
+type Address struct {
+	ID        string
+	AccountID string
+	// Name is the recipient
+	Name  string
+	Line1 string
+	Line2 string
+	City  string
+	// Subdivision is the ISO 3166-2 subdivision code without the country prefix,
+	// e.g. "CA" for California; empty for countries without one
+	Subdivision string
+	PostalCode  string
+	// Country is the ISO 3166-1 alpha-2 code, e.g. "US"
+	Country         string
+	DefaultShipping bool
+	DefaultBilling  bool
+}
+
+// Region returns the ISO 3166-2 code of the region of the address, e.g.
+// "US-CA", or the country code if it has no subdivision
+func (a Address) Region() string {
+	if a.Subdivision == "" {
+		return a.Country
+	}
+	return a.Country + "-" + a.Subdivision
+}
+
+// Validate trims the fields of the address, upper-cases its codes and checks it
+// fits the addresses table
+func (a *Address) Validate() error {
+	fields := []struct {
+		name     string
+		value    *string
+		required bool
+		max      int
+	}{
+		{"name", &a.Name, true, 64},
+		{"line1", &a.Line1, true, 128},
+		{"line2", &a.Line2, false, 128},
+		{"city", &a.City, true, 64},
+		{"postal code", &a.PostalCode, false, 16},
+	}
+	for _, field := range fields {
+		*field.value = strings.TrimSpace(*field.value)
+		if field.required && *field.value == "" {
+			return fmt.Errorf("%w: %s must not be empty", ErrInvalidAddress, field.name)
+		}
+		if utf8.RuneCountInString(*field.value) > field.max {
+			return fmt.Errorf("%w: %s must be at most %d characters", ErrInvalidAddress, field.name, field.max)
+		}
+	}
+	a.Country = strings.ToUpper(strings.TrimSpace(a.Country))
+	if len(a.Country) != 2 || !isAlphanumeric(a.Country, false) {
+		return fmt.Errorf("%w: country %q is not an ISO 3166-1 alpha-2 code", ErrInvalidAddress, a.Country)
+	}
+	a.Subdivision = strings.ToUpper(strings.TrimSpace(a.Subdivision))
+	// Accept "US-CA" as well as "CA"
+	a.Subdivision = strings.TrimPrefix(a.Subdivision, a.Country+"-")
+	if len(a.Subdivision) > 3 || !isAlphanumeric(a.Subdivision, true) {
+		return fmt.Errorf("%w: subdivision %q is not an ISO 3166-2 code", ErrInvalidAddress, a.Subdivision)
+	}
+	return nil
+}
+
+// isAlphanumeric reports whether s only has upper-case ASCII letters, and digits
+// if digits is set
+func isAlphanumeric(s string, digits bool) bool {
+	for _, c := range s {
+		if (c < 'A' || c > 'Z') && (!digits || c < '0' || c > '9') {
+			return false
+		}
+	}
+	return true
+}
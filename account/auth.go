@@ -0,0 +1,58 @@
+package account
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// minPasswordLength is the shortest password Register accepts; bcrypt ignores
+	// everything after maxPasswordLength bytes, so longer passwords are rejected
+	minPasswordLength = 8
+	maxPasswordLength = 72
+	// maxEmailLength matches the VARCHAR(254) email column in up.sql
+	maxEmailLength = 254
+)
+
+var (
+	ErrInvalidCredentials = errs.Unauthenticated("invalid email or password")
+	ErrEmailTaken         = errs.AlreadyExists("email is already registered")
+)
+
+// dummyHash is compared against when no account has the email, so Login takes
+// as long for unknown emails as for wrong passwords
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), bcrypt.DefaultCost)
+
+// normalizeEmail trims and lower-cases the email and checks it is a bare address
+func normalizeEmail(email string) (string, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email == "" {
+		return "", errs.InvalidArgument("email must not be empty")
+	}
+	if len(email) > maxEmailLength {
+		return "", errs.InvalidArgument(fmt.Sprintf("email must be at most %d characters", maxEmailLength))
+	}
+	parsed, err := mail.ParseAddress(email)
+	if err != nil || parsed.Address != email {
+		return "", errs.InvalidArgument(fmt.Sprintf("%q is not a valid email address", email))
+	}
+	return email, nil
+}
+
+func hashPassword(password string) (string, error) {
+	if len(password) < minPasswordLength {
+		return "", errs.InvalidArgument(fmt.Sprintf("password must be at least %d characters", minPasswordLength))
+	}
+	if len(password) > maxPasswordLength {
+		return "", errs.InvalidArgument(fmt.Sprintf("password must be at most %d bytes", maxPasswordLength))
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/sdshah09/GoCore/account/pb"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -13,8 +15,14 @@ type Client struct {
 	service pb.AccountServiceClient
 }
 
-func NewClient(url string) (*Client, error) {
-	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewClient connects to the account service at url and authenticates every call
+// with a token from tokens
+func NewClient(url string, tokens auth.TokenSource) (*Client, error) {
+	conn, err := grpc.NewClient(
+		url,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(errs.UnaryClientInterceptor, auth.UnaryClientInterceptor(tokens)),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -30,18 +38,15 @@ func (client *Client) Close() {
 	client.conn.Close()
 }
 
-func (client *Client) PostAccount(ctx context.Context, name string) (*Account, error) {
+func (client *Client) PostAccount(ctx context.Context, name string, email string, currency string, idempotencyKey string) (*Account, error) {
 	res, err := client.service.PostAccount(
 		ctx,
-		&pb.PostAccountRequest{Name: name},
+		&pb.PostAccountRequest{Name: name, Email: email, Currency: currency, IdempotencyKey: idempotencyKey},
 	)
 	if err != nil {
 		return nil, err
 	}
-	return &Account{
-		ID:   res.Account.Id,
-		Name: res.Account.Name,
-	}, nil
+	return accountFromProto(res.Account), nil
 }
 
 func (client *Client) GetAccount(ctx context.Context, id string) (*Account, error) {
@@ -52,27 +57,145 @@ func (client *Client) GetAccount(ctx context.Context, id string) (*Account, erro
 	if err != nil {
 		return nil, err
 	}
-	return &Account{
-		ID:   res.Account.Id,
-		Name: res.Account.Name,
-	}, nil
+	return accountFromProto(res.Account), nil
+}
+
+func (client *Client) GetAccountByEmail(ctx context.Context, email string) (*Account, error) {
+	res, err := client.service.GetAccountByEmail(ctx, &pb.GetAccountByEmailRequest{Email: email})
+	if err != nil {
+		return nil, err
+	}
+	return accountFromProto(res.Account), nil
 }
 
-func (client *Client) GetAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error) {
+// GetAccounts returns a page of accounts after the cursor after, and whether
+// there is a next page
+func (client *Client) GetAccounts(ctx context.Context, after string, first uint64, includeDeleted bool) ([]Account, bool, error) {
 	res, err := client.service.GetAccounts(
 		ctx,
-		&pb.GetAccountsRequest{Skip: skip, Take: take},
+		&pb.GetAccountsRequest{After: after, First: first, IncludeDeleted: includeDeleted},
 	)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	var accounts []Account
 	for _, pbAccount := range res.Accounts {
-		accounts = append(accounts, Account{
-			ID:   pbAccount.Id,
-			Name: pbAccount.Name,
-		})
+		accounts = append(accounts, *accountFromProto(pbAccount))
 	}
 
-	return accounts, nil
+	return accounts, res.HasNextPage, nil
+}
+
+func (client *Client) UpdateAccount(ctx context.Context, id string, name string, currency string) (*Account, error) {
+	res, err := client.service.UpdateAccount(
+		ctx,
+		&pb.UpdateAccountRequest{Id: id, Name: name, Currency: currency},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return accountFromProto(res.Account), nil
+}
+
+func (client *Client) DeleteAccount(ctx context.Context, id string) error {
+	_, err := client.service.DeleteAccount(ctx, &pb.DeleteAccountRequest{Id: id})
+	return err
+}
+
+// PostAddress adds an address to the account a.AccountID
+func (client *Client) PostAddress(ctx context.Context, a Address) (*Address, error) {
+	res, err := client.service.PostAddress(ctx, &pb.PostAddressRequest{Address: addressToProto(&a)})
+	if err != nil {
+		return nil, err
+	}
+	address := addressFromProto(res.Address)
+	return &address, nil
+}
+
+func (client *Client) GetAddress(ctx context.Context, id string) (*Address, error) {
+	res, err := client.service.GetAddress(ctx, &pb.GetAddressRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	address := addressFromProto(res.Address)
+	return &address, nil
+}
+
+func (client *Client) GetAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	res, err := client.service.GetAddresses(ctx, &pb.GetAddressesRequest{AccountId: accountID})
+	if err != nil {
+		return nil, err
+	}
+	addresses := []Address{}
+	for _, pbAddress := range res.Addresses {
+		addresses = append(addresses, addressFromProto(pbAddress))
+	}
+	return addresses, nil
+}
+
+func (client *Client) UpdateAddress(ctx context.Context, a Address) (*Address, error) {
+	res, err := client.service.UpdateAddress(ctx, &pb.UpdateAddressRequest{Address: addressToProto(&a)})
+	if err != nil {
+		return nil, err
+	}
+	address := addressFromProto(res.Address)
+	return &address, nil
+}
+
+func (client *Client) DeleteAddress(ctx context.Context, id string) error {
+	_, err := client.service.DeleteAddress(ctx, &pb.DeleteAddressRequest{Id: id})
+	return err
+}
+
+// Register creates an account that logs in with email and password
+func (client *Client) Register(ctx context.Context, name string, email string, password string, currency string) (*Account, *auth.Tokens, error) {
+	res, err := client.service.Register(ctx, &pb.RegisterRequest{
+		Name:     name,
+		Email:    email,
+		Password: password,
+		Currency: currency,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountFromProto(res.Account), tokensFromProto(res.Tokens), nil
+}
+
+func (client *Client) Login(ctx context.Context, email string, password string) (*Account, *auth.Tokens, error) {
+	res, err := client.service.Login(ctx, &pb.LoginRequest{Email: email, Password: password})
+	if err != nil {
+		return nil, nil, err
+	}
+	return accountFromProto(res.Account), tokensFromProto(res.Tokens), nil
+}
+
+func (client *Client) RefreshToken(ctx context.Context, refreshToken string) (*auth.Tokens, error) {
+	res, err := client.service.RefreshToken(ctx, &pb.RefreshTokenRequest{RefreshToken: refreshToken})
+	if err != nil {
+		return nil, err
+	}
+	return tokensFromProto(res.Tokens), nil
+}
+
+func accountFromProto(pbAccount *pb.Account) *Account {
+	a := &Account{
+		ID:       pbAccount.Id,
+		Name:     pbAccount.Name,
+		Currency: pbAccount.Currency,
+		Email:    pbAccount.Email,
+		Role:     auth.Role(pbAccount.Role),
+	}
+	if pbAccount.DeletedAt != nil {
+		deletedAt := pbAccount.DeletedAt.AsTime()
+		a.DeletedAt = &deletedAt
+	}
+	return a
+}
+
+func tokensFromProto(t *pb.Tokens) *auth.Tokens {
+	return &auth.Tokens{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		ExpiresAt:    t.ExpiresAt.AsTime(),
+	}
 }
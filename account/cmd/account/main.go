@@ -9,6 +9,7 @@ import (
 
 	"github.com/kelseyhightower/envconfig"
 	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
 	"github.com/tinrab/retry"
 )
 
@@ -18,6 +19,14 @@ type Config struct {
 	DBName     string `envconfig:"DB_NAME"`
 	DBUser     string `envconfig:"DB_USER"`
 	DBPassword string `envconfig:"DB_PASSWORD"`
+	HealthPort int    `envconfig:"HEALTH_PORT" default:"8080"`
+	// Repository selects the storage backend: "postgres" (default) or "memory"
+	Repository string `envconfig:"REPOSITORY" default:"postgres"`
+	// JWTSecret signs the access and refresh tokens; the other services and the
+	// GraphQL gateway verify access tokens with the same secret
+	JWTSecret       string        `envconfig:"JWT_SECRET" required:"true"`
+	AccessTokenTTL  time.Duration `envconfig:"ACCESS_TOKEN_TTL" default:"15m"`
+	RefreshTokenTTL time.Duration `envconfig:"REFRESH_TOKEN_TTL" default:"168h"`
 }
 
 func (c Config) DatabaseURL() string {
@@ -37,16 +46,26 @@ func main() {
 		log.Fatal(err)
 	}
 
+	tokens, err := auth.NewTokenManager([]byte(cfg.JWTSecret), cfg.AccessTokenTTL, cfg.RefreshTokenTTL)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	var repo account.Repository
-	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
-		repo, err = account.NewPostgresRepository(cfg.DatabaseURL())
-		if err != nil {
-			log.Println(err)
-		}
-		return
-	})
+	if cfg.Repository == "memory" {
+		log.Println("Using in-memory repository, data is lost on restart")
+		repo = account.NewMemoryRepository()
+	} else {
+		retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+			repo, err = account.NewPostgresRepository(cfg.DatabaseURL())
+			if err != nil {
+				log.Println(err)
+			}
+			return
+		})
+	}
 	defer repo.Close()
-	
+
 	// Start HTTP health check server
 	go func() {
 		http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -63,12 +82,12 @@ func main() {
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("OK"))
 		})
-		log.Println("Health check server listening on :8080")
-		log.Fatal(http.ListenAndServe(":8080", nil))
+		log.Printf("Health check server listening on :%d", cfg.HealthPort)
+		log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", cfg.HealthPort), nil))
 	}()
-	
+
 	log.Println("Listening on Port 8081...")
-	service := account.NewService(repo)
-	log.Fatal(account.ListenGRPC(service, 8081))
+	service := account.NewService(repo, tokens)
+	log.Fatal(account.ListenGRPC(service, tokens, 8081))
 
 }
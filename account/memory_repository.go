@@ -0,0 +1,232 @@
+package account
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// memoryRepository keeps accounts in process memory. It is meant for tests and
+// local demos; nothing survives a restart.
+type memoryRepository struct {
+	mu              sync.RWMutex
+	accounts        map[string]Account
+	addresses       map[string]Address
+	idempotencyKeys map[idempotencyKeyID]idempotencyKey
+	// passwordHashes are keyed by account ID
+	passwordHashes map[string]string
+}
+
+// idempotencyKeyID scopes an idempotency key to the caller that reserved it
+type idempotencyKeyID struct {
+	caller string
+	key    string
+}
+
+type idempotencyKey struct {
+	accountID string
+	expiresAt time.Time
+}
+
+func NewMemoryRepository() Repository {
+	return &memoryRepository{
+		accounts:        map[string]Account{},
+		addresses:       map[string]Address{},
+		idempotencyKeys: map[idempotencyKeyID]idempotencyKey{},
+		passwordHashes:  map[string]string{},
+	}
+}
+
+func (r *memoryRepository) Close() error {
+	return nil
+}
+
+func (r *memoryRepository) Ping() error {
+	return nil
+}
+
+func (r *memoryRepository) PutAccount(ctx context.Context, a Account, passwordHash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.accounts[a.ID]; exists {
+		return errs.AlreadyExists("account already exists")
+	}
+	if a.Email != "" {
+		for _, other := range r.accounts {
+			if other.Email == a.Email {
+				return ErrEmailTaken
+			}
+		}
+	}
+	r.accounts[a.ID] = a
+	if passwordHash != "" {
+		r.passwordHashes[a.ID] = passwordHash
+	}
+	return nil
+}
+
+func (r *memoryRepository) GetAccountByID(ctx context.Context, id string) (*Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, exists := r.accounts[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &a, nil
+}
+
+func (r *memoryRepository) GetAccountByEmail(ctx context.Context, email string) (*Account, error) {
+	a, _, err := r.GetCredentials(ctx, email)
+	return a, err
+}
+
+func (r *memoryRepository) GetCredentials(ctx context.Context, email string) (*Account, string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, a := range r.accounts {
+		if a.Email != "" && a.Email == email {
+			return &a, r.passwordHashes[a.ID], nil
+		}
+	}
+	return nil, "", ErrNotFound
+}
+
+func (r *memoryRepository) ListAccounts(ctx context.Context, afterID string, limit uint64, includeDeleted bool) ([]Account, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	accounts := []Account{}
+	for _, a := range r.accounts {
+		if (includeDeleted || a.DeletedAt == nil) && (afterID == "" || a.ID > afterID) {
+			accounts = append(accounts, a)
+		}
+	}
+	// Same order as the Postgres repository
+	sort.Slice(accounts, func(i, j int) bool {
+		return accounts[i].ID < accounts[j].ID
+	})
+	if limit < uint64(len(accounts)) {
+		accounts = accounts[:limit]
+	}
+	return accounts, nil
+}
+
+func (r *memoryRepository) UpdateAccount(ctx context.Context, a Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, exists := r.accounts[a.ID]
+	if !exists || existing.DeletedAt != nil {
+		return ErrNotFound
+	}
+	existing.Name = a.Name
+	if a.Currency != "" {
+		existing.Currency = a.Currency
+	}
+	r.accounts[a.ID] = existing
+	return nil
+}
+
+func (r *memoryRepository) DeleteAccount(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, exists := r.accounts[id]
+	if !exists || existing.DeletedAt != nil {
+		return ErrNotFound
+	}
+	now := time.Now()
+	existing.DeletedAt = &now
+	r.accounts[id] = existing
+	return nil
+}
+
+func (r *memoryRepository) ReserveIdempotencyKey(ctx context.Context, caller string, key string, accountID string, ttl time.Duration) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := idempotencyKeyID{caller: caller, key: key}
+	if existing, exists := r.idempotencyKeys[id]; exists && existing.expiresAt.After(time.Now()) {
+		return existing.accountID, nil
+	}
+	r.idempotencyKeys[id] = idempotencyKey{accountID: accountID, expiresAt: time.Now().Add(ttl)}
+	return accountID, nil
+}
+
+func (r *memoryRepository) ReleaseIdempotencyKey(ctx context.Context, caller string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.idempotencyKeys, idempotencyKeyID{caller: caller, key: key})
+	return nil
+}
+
+func (r *memoryRepository) PutAddress(ctx context.Context, a Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.addresses[a.ID]; exists {
+		return errs.AlreadyExists("address already exists")
+	}
+	r.clearDefaults(a)
+	r.addresses[a.ID] = a
+	return nil
+}
+
+func (r *memoryRepository) GetAddressByID(ctx context.Context, id string) (*Address, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	a, exists := r.addresses[id]
+	if !exists {
+		return nil, ErrAddressNotFound
+	}
+	return &a, nil
+}
+
+func (r *memoryRepository) ListAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	addresses := []Address{}
+	for _, a := range r.addresses {
+		if a.AccountID == accountID {
+			addresses = append(addresses, a)
+		}
+	}
+	sort.Slice(addresses, func(i, j int) bool {
+		return addresses[i].ID < addresses[j].ID
+	})
+	return addresses, nil
+}
+
+func (r *memoryRepository) UpdateAddress(ctx context.Context, a Address) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing, exists := r.addresses[a.ID]
+	if !exists {
+		return ErrAddressNotFound
+	}
+	a.AccountID = existing.AccountID
+	r.clearDefaults(a)
+	r.addresses[a.ID] = a
+	return nil
+}
+
+func (r *memoryRepository) DeleteAddress(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.addresses[id]; !exists {
+		return ErrAddressNotFound
+	}
+	delete(r.addresses, id)
+	return nil
+}
+
+// clearDefaults unsets the default flags a sets on the other addresses of its
+// account. The caller must hold the write lock.
+func (r *memoryRepository) clearDefaults(a Address) {
+	for id, other := range r.addresses {
+		if id == a.ID || other.AccountID != a.AccountID {
+			continue
+		}
+		other.DefaultShipping = other.DefaultShipping && !a.DefaultShipping
+		other.DefaultBilling = other.DefaultBilling && !a.DefaultBilling
+		r.addresses[id] = other
+	}
+}
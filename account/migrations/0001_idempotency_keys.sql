@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+INSERT INTO schema_migrations (version) VALUES (1);
+
+CREATE TABLE idempotency_keys (
+    caller VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    account_id CHAR(27) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (caller, key)
+);
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (2);
+
+ALTER TABLE accounts ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+COMMIT;
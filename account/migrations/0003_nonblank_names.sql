@@ -0,0 +1,9 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (3);
+
+-- Not validated, so existing blank names do not fail the migration; they are
+-- rejected when the account is next written
+ALTER TABLE accounts ADD CONSTRAINT accounts_name_check CHECK (btrim(name) <> '') NOT VALID;
+
+COMMIT;
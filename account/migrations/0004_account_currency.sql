@@ -0,0 +1,7 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (4);
+
+ALTER TABLE accounts ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+
+COMMIT;
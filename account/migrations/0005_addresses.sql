@@ -0,0 +1,23 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (5);
+
+CREATE TABLE addresses (
+    id CHAR(27) PRIMARY KEY,
+    account_id CHAR(27) NOT NULL REFERENCES accounts (id),
+    name VARCHAR(64) NOT NULL,
+    line1 VARCHAR(128) NOT NULL,
+    line2 VARCHAR(128) NOT NULL DEFAULT '',
+    city VARCHAR(64) NOT NULL,
+    subdivision VARCHAR(3) NOT NULL DEFAULT '',
+    postal_code VARCHAR(16) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL,
+    default_shipping BOOLEAN NOT NULL DEFAULT FALSE,
+    default_billing BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX addresses_account_id_idx ON addresses (account_id);
+CREATE UNIQUE INDEX addresses_default_shipping_idx ON addresses (account_id) WHERE default_shipping;
+CREATE UNIQUE INDEX addresses_default_billing_idx ON addresses (account_id) WHERE default_billing;
+
+COMMIT;
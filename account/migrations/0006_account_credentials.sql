@@ -0,0 +1,9 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (6);
+
+ALTER TABLE accounts ADD COLUMN email VARCHAR(254);
+ALTER TABLE accounts ADD COLUMN password_hash TEXT;
+CREATE UNIQUE INDEX accounts_email_idx ON accounts (email);
+
+COMMIT;
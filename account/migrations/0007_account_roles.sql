@@ -0,0 +1,8 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (7);
+
+ALTER TABLE accounts ADD COLUMN role VARCHAR(16) NOT NULL DEFAULT 'customer'
+    CHECK (role IN ('customer', 'admin'));
+
+COMMIT;
@@ -3,16 +3,50 @@ package account
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
+	"github.com/sdshah09/GoCore/errs"
+)
+
+var ErrNotFound = errs.NotFound("account not found")
+
+// Postgres error codes translated into domain errors, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	pqUniqueViolation  = "23505"
+	pqStringTruncation = "22001"
+	pqCheckViolation   = "23514"
 )
 
 type Repository interface {
 	Close() error
 	Ping() error
-	PutAccount(ctx context.Context, a Account) error
+	// PutAccount stores the account with the bcrypt hash of its password, or
+	// without credentials if passwordHash is empty
+	PutAccount(ctx context.Context, a Account, passwordHash string) error
 	GetAccountByID(ctx context.Context, id string) (*Account, error)
-	ListAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error)
+	GetAccountByEmail(ctx context.Context, email string) (*Account, error)
+	// GetCredentials returns the account with the email and its password hash
+	GetCredentials(ctx context.Context, email string) (*Account, string, error)
+	// ListAccounts returns up to limit accounts in ID order, starting after the
+	// account with ID afterID, or from the first if it is empty
+	ListAccounts(ctx context.Context, afterID string, limit uint64, includeDeleted bool) ([]Account, error)
+	UpdateAccount(ctx context.Context, a Account) error
+	DeleteAccount(ctx context.Context, id string) error
+	// ReserveIdempotencyKey binds the key of the caller to accountID unless an
+	// unexpired binding already exists, and returns the account ID the key is
+	// bound to
+	ReserveIdempotencyKey(ctx context.Context, caller string, key string, accountID string, ttl time.Duration) (string, error)
+	ReleaseIdempotencyKey(ctx context.Context, caller string, key string) error
+	// PutAddress and UpdateAddress unset the default flags of the other addresses
+	// of the account when they set them on a
+	PutAddress(ctx context.Context, a Address) error
+	GetAddressByID(ctx context.Context, id string) (*Address, error)
+	ListAddresses(ctx context.Context, accountID string) ([]Address, error)
+	UpdateAddress(ctx context.Context, a Address) error
+	DeleteAddress(ctx context.Context, id string) error
 }
 
 type postgresRepository struct {
@@ -41,26 +75,77 @@ func (r *postgresRepository) Close() error {
 	return nil
 }
 
-func (r *postgresRepository) PutAccount(ctx context.Context, a Account) error {
-	r.db.ExecContext(ctx, "INSERT INTO accounts(id, name) VALUES($1, $2)", a.ID, a.Name)
-	return nil
+func (r *postgresRepository) PutAccount(ctx context.Context, a Account, passwordHash string) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		"INSERT INTO accounts(id, name, currency, email, password_hash, role) VALUES($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6)",
+		a.ID,
+		a.Name,
+		a.Currency,
+		a.Email,
+		passwordHash,
+		a.Role,
+	)
+	return translateError(err)
 }
 
-func (r *postgresRepository) GetAccountByID(ctx context.Context, id string) (*Account, error) {
-	row := r.db.QueryRowContext(ctx, "SELECT id, name FROM accounts WHERE id = $1", id)
+// accountColumns are the columns scanned by scanAccount
+const accountColumns = "id, name, currency, deleted_at, COALESCE(email, ''), role"
+
+func scanAccount(row scanner, dest ...interface{}) (*Account, error) {
 	a := &Account{}
-	if err := row.Scan(&a.ID, &a.Name); err != nil {
+	var deletedAt sql.NullTime
+	if err := row.Scan(append([]interface{}{&a.ID, &a.Name, &a.Currency, &deletedAt, &a.Email, &a.Role}, dest...)...); err != nil {
 		return nil, err
 	}
+	if deletedAt.Valid {
+		a.DeletedAt = &deletedAt.Time
+	}
 	return a, nil
 }
 
-func (r *postgresRepository) ListAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error) {
+// GetAccountByID also returns deleted accounts, so orders placed by them can still be resolved
+func (r *postgresRepository) GetAccountByID(ctx context.Context, id string) (*Account, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+accountColumns+" FROM accounts WHERE id = $1", id)
+	a, err := scanAccount(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return a, err
+}
+
+// GetAccountByEmail also returns deleted accounts, like GetAccountByID
+func (r *postgresRepository) GetAccountByEmail(ctx context.Context, email string) (*Account, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+accountColumns+" FROM accounts WHERE email = $1", email)
+	a, err := scanAccount(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	return a, err
+}
+
+func (r *postgresRepository) GetCredentials(ctx context.Context, email string) (*Account, string, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+accountColumns+", COALESCE(password_hash, '') FROM accounts WHERE email = $1", email)
+	var passwordHash string
+	a, err := scanAccount(row, &passwordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, "", ErrNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return a, passwordHash, nil
+}
+
+// ListAccounts pages with the primary key rather than OFFSET, so a page costs the
+// same however deep it is and concurrent inserts do not shift later pages
+func (r *postgresRepository) ListAccounts(ctx context.Context, afterID string, limit uint64, includeDeleted bool) ([]Account, error) {
 	rows, err := r.db.QueryContext(
 		ctx,
-		"SELECT id, name FROM accounts ORDER BY id DESC OFFSET $1 LIMIT $2",
-		skip,
-		take,
+		"SELECT "+accountColumns+" FROM accounts WHERE ($1 OR deleted_at IS NULL) AND ($2 = '' OR id > $2) ORDER BY id LIMIT $3",
+		includeDeleted,
+		afterID,
+		limit,
 	)
 	if err != nil {
 		return nil, err
@@ -69,10 +154,11 @@ func (r *postgresRepository) ListAccounts(ctx context.Context, skip uint64, take
 	accounts := []Account{}
 
 	for rows.Next() {
-		a := &Account{}
-		if err = rows.Scan(&a.ID, &a.Name); err == nil {
-			accounts = append(accounts, *a)
+		a, err := scanAccount(rows)
+		if err != nil {
+			return nil, err
 		}
+		accounts = append(accounts, *a)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -80,3 +166,261 @@ func (r *postgresRepository) ListAccounts(ctx context.Context, skip uint64, take
 	}
 	return accounts, nil
 }
+
+// UpdateAccount sets the name of the account, and its currency unless a.Currency is empty
+func (r *postgresRepository) UpdateAccount(ctx context.Context, a Account) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		"UPDATE accounts SET name = $2, currency = COALESCE(NULLIF($3, ''), currency) WHERE id = $1 AND deleted_at IS NULL",
+		a.ID,
+		a.Name,
+		a.Currency,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	return requireAffected(res)
+}
+
+// DeleteAccount soft deletes the account by setting deleted_at
+func (r *postgresRepository) DeleteAccount(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, "UPDATE accounts SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL", id)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+// translateError turns constraint violations into domain errors so callers get
+// a meaningful status instead of a raw driver error
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return err
+	}
+	switch pqErr.Code {
+	case pqUniqueViolation:
+		if pqErr.Constraint == "accounts_email_idx" {
+			return ErrEmailTaken
+		}
+		return errs.AlreadyExists("account already exists")
+	case pqStringTruncation:
+		return errs.InvalidArgument("account field is too long")
+	case pqCheckViolation:
+		return errs.InvalidArgument("account violates constraint " + pqErr.Constraint)
+	}
+	return err
+}
+
+// requireAffected returns ErrNotFound if the statement did not touch any live account
+func requireAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func (r *postgresRepository) ReserveIdempotencyKey(ctx context.Context, caller string, key string, accountID string, ttl time.Duration) (string, error) {
+	var reservedID string
+	err := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO idempotency_keys(caller, key, account_id, expires_at) VALUES ($1, $2, $3, $4)
+    ON CONFLICT (caller, key) DO UPDATE SET account_id = EXCLUDED.account_id, expires_at = EXCLUDED.expires_at
+    WHERE idempotency_keys.expires_at < NOW()
+    RETURNING account_id`,
+		caller,
+		key,
+		accountID,
+		time.Now().Add(ttl),
+	).Scan(&reservedID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The key is already bound to an unexpired account
+		err = r.db.QueryRowContext(
+			ctx,
+			"SELECT account_id FROM idempotency_keys WHERE caller = $1 AND key = $2",
+			caller,
+			key,
+		).Scan(&reservedID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return reservedID, nil
+}
+
+func (r *postgresRepository) ReleaseIdempotencyKey(ctx context.Context, caller string, key string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE caller = $1 AND key = $2", caller, key)
+	return err
+}
+
+// addressColumns are the columns scanned by scanAddress
+const addressColumns = "id, account_id, name, line1, line2, city, subdivision, postal_code, country, default_shipping, default_billing"
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAddress(row scanner) (*Address, error) {
+	a := &Address{}
+	err := row.Scan(
+		&a.ID,
+		&a.AccountID,
+		&a.Name,
+		&a.Line1,
+		&a.Line2,
+		&a.City,
+		&a.Subdivision,
+		&a.PostalCode,
+		&a.Country,
+		&a.DefaultShipping,
+		&a.DefaultBilling,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func (r *postgresRepository) PutAddress(ctx context.Context, a Address) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	if err = clearDefaults(ctx, tx, a); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO addresses(id, account_id, name, line1, line2, city, subdivision, postal_code, country, default_shipping, default_billing)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+		a.ID,
+		a.AccountID,
+		a.Name,
+		a.Line1,
+		a.Line2,
+		a.City,
+		a.Subdivision,
+		a.PostalCode,
+		a.Country,
+		a.DefaultShipping,
+		a.DefaultBilling,
+	)
+	return translateError(err)
+}
+
+func (r *postgresRepository) GetAddressByID(ctx context.Context, id string) (*Address, error) {
+	row := r.db.QueryRowContext(ctx, "SELECT "+addressColumns+" FROM addresses WHERE id = $1", id)
+	a, err := scanAddress(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrAddressNotFound
+	}
+	return a, err
+}
+
+func (r *postgresRepository) ListAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT "+addressColumns+" FROM addresses WHERE account_id = $1 ORDER BY id", accountID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	addresses := []Address{}
+	for rows.Next() {
+		a, err := scanAddress(rows)
+		if err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, *a)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return addresses, nil
+}
+
+// UpdateAddress replaces every field of the address except its account
+func (r *postgresRepository) UpdateAddress(ctx context.Context, a Address) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	err = tx.QueryRowContext(ctx, "SELECT account_id FROM addresses WHERE id = $1 FOR UPDATE", a.ID).Scan(&a.AccountID)
+	if errors.Is(err, sql.ErrNoRows) {
+		err = ErrAddressNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if err = clearDefaults(ctx, tx, a); err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		`UPDATE addresses SET name = $2, line1 = $3, line2 = $4, city = $5, subdivision = $6, postal_code = $7, country = $8,
+      default_shipping = $9, default_billing = $10
+    WHERE id = $1`,
+		a.ID,
+		a.Name,
+		a.Line1,
+		a.Line2,
+		a.City,
+		a.Subdivision,
+		a.PostalCode,
+		a.Country,
+		a.DefaultShipping,
+		a.DefaultBilling,
+	)
+	return translateError(err)
+}
+
+func (r *postgresRepository) DeleteAddress(ctx context.Context, id string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM addresses WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAddressNotFound
+	}
+	return nil
+}
+
+// clearDefaults unsets the default flags a sets on the other addresses of its
+// account, so each account keeps at most one default of each kind
+func clearDefaults(ctx context.Context, tx *sql.Tx, a Address) error {
+	if !a.DefaultShipping && !a.DefaultBilling {
+		return nil
+	}
+	_, err := tx.ExecContext(
+		ctx,
+		`UPDATE addresses SET
+      default_shipping = default_shipping AND NOT $3,
+      default_billing = default_billing AND NOT $4
+    WHERE account_id = $1 AND id <> $2`,
+		a.AccountID,
+		a.ID,
+		a.DefaultShipping,
+		a.DefaultBilling,
+	)
+	return err
+}
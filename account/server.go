@@ -6,7 +6,10 @@ import (
 	"net"
 
 	"github.com/sdshah09/GoCore/account/pb"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type grpcServer struct {
@@ -14,40 +17,64 @@ type grpcServer struct {
 	service Service
 }
 
-func ListenGRPC(s Service, port int) error {
+// policy lets anyone register and log in; accounts and their addresses are
+// checked against the caller in the handlers
+var policy = auth.Policy{
+	pb.AccountService_PostAccount_FullMethodName:       auth.Admin,
+	pb.AccountService_GetAccount_FullMethodName:        auth.Customer,
+	pb.AccountService_GetAccountByEmail_FullMethodName: auth.Admin,
+	pb.AccountService_GetAccounts_FullMethodName:       auth.Admin,
+	pb.AccountService_UpdateAccount_FullMethodName:     auth.Customer,
+	pb.AccountService_DeleteAccount_FullMethodName:     auth.Customer,
+	pb.AccountService_PostAddress_FullMethodName:       auth.Customer,
+	pb.AccountService_GetAddress_FullMethodName:        auth.Customer,
+	pb.AccountService_GetAddresses_FullMethodName:      auth.Customer,
+	pb.AccountService_UpdateAddress_FullMethodName:     auth.Customer,
+	pb.AccountService_DeleteAddress_FullMethodName:     auth.Customer,
+	pb.AccountService_Register_FullMethodName:          auth.Public,
+	pb.AccountService_Login_FullMethodName:             auth.Public,
+	pb.AccountService_RefreshToken_FullMethodName:      auth.Public,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
 	}
-	serv := grpc.NewServer()
+	serv := grpc.NewServer(grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor, auth.UnaryServerInterceptor(tokens, policy)))
 	pb.RegisterAccountServiceServer(serv, &grpcServer{service: s})
 	return serv.Serve(lis)
 }
 
 func (s *grpcServer) PostAccount(ctx context.Context, r *pb.PostAccountRequest) (*pb.PostAccountResponse, error) {
-	a, err := s.service.PostAccount(ctx, r.Name)
+	a, err := s.service.PostAccount(ctx, r.Name, r.Email, r.Currency, r.IdempotencyKey)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.PostAccountResponse{Account: &pb.Account{
-		Id:   a.ID,
-		Name: a.Name,
-	}}, nil
+	return &pb.PostAccountResponse{Account: accountToProto(a)}, nil
 }
 
 func (s *grpcServer) GetAccount(ctx context.Context, r *pb.GetAccountRequest) (*pb.GetAccountResponse, error) {
+	if err := auth.CheckAccount(ctx, r.Id); err != nil {
+		return nil, err
+	}
 	a, err := s.service.GetAccount(ctx, r.Id)
 	if err != nil {
 		return nil, err
 	}
-	return &pb.GetAccountResponse{Account: &pb.Account{
-		Id:   a.ID,
-		Name: a.Name,
-	}}, nil
+	return &pb.GetAccountResponse{Account: accountToProto(a)}, nil
+}
+
+func (s *grpcServer) GetAccountByEmail(ctx context.Context, r *pb.GetAccountByEmailRequest) (*pb.GetAccountByEmailResponse, error) {
+	a, err := s.service.GetAccountByEmail(ctx, r.Email)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAccountByEmailResponse{Account: accountToProto(a)}, nil
 }
 
 func (s *grpcServer) GetAccounts(ctx context.Context, r *pb.GetAccountsRequest) (*pb.GetAccountsResponse, error) {
-	accounts, err := s.service.GetAccounts(ctx, r.Skip, r.Take)
+	accounts, hasNextPage, err := s.service.GetAccounts(ctx, r.After, r.First, r.IncludeDeleted)
 	if err != nil {
 		return nil, err
 	}
@@ -55,13 +82,183 @@ func (s *grpcServer) GetAccounts(ctx context.Context, r *pb.GetAccountsRequest)
 	// Convert domain accounts to protobuf accounts
 	var pbAccounts []*pb.Account
 	for _, account := range accounts {
-		pbAccounts = append(pbAccounts, &pb.Account{
-			Id:   account.ID,
-			Name: account.Name,
-		})
+		pbAccounts = append(pbAccounts, accountToProto(&account))
 	}
 
 	return &pb.GetAccountsResponse{
-		Accounts: pbAccounts,
+		Accounts:    pbAccounts,
+		HasNextPage: hasNextPage,
 	}, nil
 }
+
+func (s *grpcServer) UpdateAccount(ctx context.Context, r *pb.UpdateAccountRequest) (*pb.UpdateAccountResponse, error) {
+	if err := auth.CheckAccount(ctx, r.Id); err != nil {
+		return nil, err
+	}
+	a, err := s.service.UpdateAccount(ctx, r.Id, r.Name, r.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateAccountResponse{Account: accountToProto(a)}, nil
+}
+
+func (s *grpcServer) DeleteAccount(ctx context.Context, r *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	if err := auth.CheckAccount(ctx, r.Id); err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteAccount(ctx, r.Id); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (s *grpcServer) PostAddress(ctx context.Context, r *pb.PostAddressRequest) (*pb.PostAddressResponse, error) {
+	if r.Address == nil {
+		return nil, errs.InvalidArgument("address is required")
+	}
+	if err := auth.CheckAccount(ctx, r.Address.AccountId); err != nil {
+		return nil, err
+	}
+	a, err := s.service.PostAddress(ctx, addressFromProto(r.Address))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PostAddressResponse{Address: addressToProto(a)}, nil
+}
+
+func (s *grpcServer) GetAddress(ctx context.Context, r *pb.GetAddressRequest) (*pb.GetAddressResponse, error) {
+	a, err := s.ownAddress(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetAddressResponse{Address: addressToProto(a)}, nil
+}
+
+func (s *grpcServer) GetAddresses(ctx context.Context, r *pb.GetAddressesRequest) (*pb.GetAddressesResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	addresses, err := s.service.GetAddresses(ctx, r.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	pbAddresses := []*pb.Address{}
+	for _, a := range addresses {
+		pbAddresses = append(pbAddresses, addressToProto(&a))
+	}
+	return &pb.GetAddressesResponse{Addresses: pbAddresses}, nil
+}
+
+func (s *grpcServer) UpdateAddress(ctx context.Context, r *pb.UpdateAddressRequest) (*pb.UpdateAddressResponse, error) {
+	if r.Address == nil {
+		return nil, errs.InvalidArgument("address is required")
+	}
+	if _, err := s.ownAddress(ctx, r.Address.Id); err != nil {
+		return nil, err
+	}
+	a, err := s.service.UpdateAddress(ctx, addressFromProto(r.Address))
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateAddressResponse{Address: addressToProto(a)}, nil
+}
+
+func (s *grpcServer) DeleteAddress(ctx context.Context, r *pb.DeleteAddressRequest) (*pb.DeleteAddressResponse, error) {
+	if _, err := s.ownAddress(ctx, r.Id); err != nil {
+		return nil, err
+	}
+	if err := s.service.DeleteAddress(ctx, r.Id); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteAddressResponse{}, nil
+}
+
+func (s *grpcServer) Register(ctx context.Context, r *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	a, tokens, err := s.service.Register(ctx, r.Name, r.Email, r.Password, r.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RegisterResponse{Account: accountToProto(a), Tokens: tokensToProto(tokens)}, nil
+}
+
+func (s *grpcServer) Login(ctx context.Context, r *pb.LoginRequest) (*pb.LoginResponse, error) {
+	a, tokens, err := s.service.Login(ctx, r.Email, r.Password)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.LoginResponse{Account: accountToProto(a), Tokens: tokensToProto(tokens)}, nil
+}
+
+func (s *grpcServer) RefreshToken(ctx context.Context, r *pb.RefreshTokenRequest) (*pb.RefreshTokenResponse, error) {
+	tokens, err := s.service.RefreshToken(ctx, r.RefreshToken)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RefreshTokenResponse{Tokens: tokensToProto(tokens)}, nil
+}
+
+// ownAddress returns the address if the caller may act on its account
+func (s *grpcServer) ownAddress(ctx context.Context, id string) (*Address, error) {
+	a, err := s.service.GetAddress(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := auth.CheckAccount(ctx, a.AccountID); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+func accountToProto(a *Account) *pb.Account {
+	account := &pb.Account{
+		Id:       a.ID,
+		Name:     a.Name,
+		Currency: a.Currency,
+		Email:    a.Email,
+		Role:     string(a.Role),
+	}
+	if a.DeletedAt != nil {
+		account.DeletedAt = timestamppb.New(*a.DeletedAt)
+	}
+	return account
+}
+
+func addressToProto(a *Address) *pb.Address {
+	return &pb.Address{
+		Id:              a.ID,
+		AccountId:       a.AccountID,
+		Name:            a.Name,
+		Line1:           a.Line1,
+		Line2:           a.Line2,
+		City:            a.City,
+		Subdivision:     a.Subdivision,
+		PostalCode:      a.PostalCode,
+		Country:         a.Country,
+		DefaultShipping: a.DefaultShipping,
+		DefaultBilling:  a.DefaultBilling,
+	}
+}
+
+func tokensToProto(t *auth.Tokens) *pb.Tokens {
+	return &pb.Tokens{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		ExpiresAt:    timestamppb.New(t.ExpiresAt),
+	}
+}
+
+func addressFromProto(a *pb.Address) Address {
+	return Address{
+		ID:              a.Id,
+		AccountID:       a.AccountId,
+		Name:            a.Name,
+		Line1:           a.Line1,
+		Line2:           a.Line2,
+		City:            a.City,
+		Subdivision:     a.Subdivision,
+		PostalCode:      a.PostalCode,
+		Country:         a.Country,
+		DefaultShipping: a.DefaultShipping,
+		DefaultBilling:  a.DefaultBilling,
+	}
+}
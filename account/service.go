@@ -2,35 +2,105 @@ package account
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
 
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+	"github.com/sdshah09/GoCore/page"
 	"github.com/segmentio/ksuid"
+	"golang.org/x/crypto/bcrypt"
 )
 
+// maxNameLength matches the VARCHAR(24) name column in up.sql
+const maxNameLength = 24
+
+// idempotencyKeyTTL is how long a replayed PostAccount returns the original account
+const idempotencyKeyTTL = 24 * time.Hour
+
 type Account struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// Currency is the ISO 4217 code orders of the account are placed in
+	Currency string `json:"currency"`
+	// Email is the lower-cased login of the account; empty for accounts created
+	// without credentials
+	Email string `json:"email,omitempty"`
+	// Role is RoleCustomer unless the account was made an admin
+	Role auth.Role `json:"role"`
 }
 
 type Service interface {
-	PostAccount(ctx context.Context, name string) (*Account, error)
+	PostAccount(ctx context.Context, name string, email string, currency string, idempotencyKey string) (*Account, error)
 	GetAccount(ctx context.Context, id string) (*Account, error)
-	GetAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error)
+	GetAccountByEmail(ctx context.Context, email string) (*Account, error)
+	GetAccounts(ctx context.Context, after string, first uint64, includeDeleted bool) ([]Account, bool, error)
+	UpdateAccount(ctx context.Context, id string, name string, currency string) (*Account, error)
+	DeleteAccount(ctx context.Context, id string) error
+	PostAddress(ctx context.Context, a Address) (*Address, error)
+	GetAddress(ctx context.Context, id string) (*Address, error)
+	GetAddresses(ctx context.Context, accountID string) ([]Address, error)
+	UpdateAddress(ctx context.Context, a Address) (*Address, error)
+	DeleteAddress(ctx context.Context, id string) error
+	Register(ctx context.Context, name string, email string, password string, currency string) (*Account, *auth.Tokens, error)
+	Login(ctx context.Context, email string, password string) (*Account, *auth.Tokens, error)
+	RefreshToken(ctx context.Context, refreshToken string) (*auth.Tokens, error)
 }
 
 type accountService struct {
 	repository Repository
+	tokens     *auth.TokenManager
 }
 
-func NewService(r Repository) Service {
-	return &accountService{r}
+func NewService(r Repository, tokens *auth.TokenManager) Service {
+	return &accountService{r, tokens}
 }
 
-func (s *accountService) PostAccount(ctx context.Context, name string) (*Account, error) {
+// PostAccount creates an account without a password. The email is optional;
+// accounts with one can be found with GetAccountByEmail but still cannot log in.
+func (s *accountService) PostAccount(ctx context.Context, name string, email string, currency string, idempotencyKey string) (*Account, error) {
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if email != "" {
+		if email, err = normalizeEmail(email); err != nil {
+			return nil, err
+		}
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if currency, err = money.ParseCurrency(currency); err != nil {
+		return nil, err
+	}
 	a := &Account{
-		Name: name,
-		ID:   ksuid.New().String(),
+		Name:     name,
+		ID:       ksuid.New().String(),
+		Currency: currency,
+		Email:    email,
+		Role:     auth.RoleCustomer,
 	}
-	if err := s.repository.PutAccount(ctx, *a); err != nil {
+	caller := auth.Caller(ctx)
+	if idempotencyKey != "" {
+		reservedID, err := s.repository.ReserveIdempotencyKey(ctx, caller, idempotencyKey, a.ID, idempotencyKeyTTL)
+		if err != nil {
+			return nil, err
+		}
+		if reservedID != a.ID {
+			// Replayed request, return the account created the first time
+			return s.repository.GetAccountByID(ctx, reservedID)
+		}
+	}
+	if err := s.repository.PutAccount(ctx, *a, ""); err != nil {
+		if idempotencyKey != "" {
+			s.repository.ReleaseIdempotencyKey(ctx, caller, idempotencyKey)
+		}
 		return nil, err
 	}
 	return a, nil
@@ -40,9 +110,197 @@ func (s *accountService) GetAccount(ctx context.Context, id string) (*Account, e
 	return s.repository.GetAccountByID(ctx, id)
 }
 
-func (s *accountService) GetAccounts(ctx context.Context, skip uint64, take uint64) ([]Account, error) {
-	if take > 100 || (skip == 0 && take == 0) { // maxium limit of 100 and if not input of skip and take given show first 100 results
-		take = 100
+// GetAccountByEmail finds the account with the email, which is compared
+// case-insensitively
+func (s *accountService) GetAccountByEmail(ctx context.Context, email string) (*Account, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+	return s.repository.GetAccountByEmail(ctx, email)
+}
+
+// GetAccounts returns a page of first accounts, oldest first, after the cursor
+// after, and whether there are more accounts after it
+func (s *accountService) GetAccounts(ctx context.Context, after string, first uint64, includeDeleted bool) ([]Account, bool, error) {
+	afterID, err := page.ID(after)
+	if err != nil {
+		return nil, false, err
+	}
+	size := page.Size(first)
+	accounts, err := s.repository.ListAccounts(ctx, afterID, size+1, includeDeleted)
+	if err != nil {
+		return nil, false, err
+	}
+	if uint64(len(accounts)) > size {
+		return accounts[:size], true, nil
+	}
+	return accounts, false, nil
+}
+
+// UpdateAccount renames the account and, if currency is not empty, changes its
+// preferred currency
+func (s *accountService) UpdateAccount(ctx context.Context, id string, name string, currency string) (*Account, error) {
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if currency != "" {
+		if currency, err = money.ParseCurrency(currency); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repository.UpdateAccount(ctx, Account{ID: id, Name: name, Currency: currency}); err != nil {
+		return nil, err
+	}
+	return s.repository.GetAccountByID(ctx, id)
+}
+
+func (s *accountService) DeleteAccount(ctx context.Context, id string) error {
+	return s.repository.DeleteAccount(ctx, id)
+}
+
+// PostAddress adds an address to a live account. The first address of an account
+// becomes its default shipping and billing address.
+func (s *accountService) PostAddress(ctx context.Context, a Address) (*Address, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	owner, err := s.repository.GetAccountByID(ctx, a.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if owner.DeletedAt != nil {
+		return nil, ErrNotFound
+	}
+	existing, err := s.repository.ListAddresses(ctx, a.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) == 0 {
+		a.DefaultShipping = true
+		a.DefaultBilling = true
+	}
+	a.ID = ksuid.New().String()
+	if err := s.repository.PutAddress(ctx, a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (s *accountService) GetAddress(ctx context.Context, id string) (*Address, error) {
+	return s.repository.GetAddressByID(ctx, id)
+}
+
+func (s *accountService) GetAddresses(ctx context.Context, accountID string) ([]Address, error) {
+	return s.repository.ListAddresses(ctx, accountID)
+}
+
+// UpdateAddress replaces the fields of the address with ID a.ID. The account an
+// address belongs to cannot be changed.
+func (s *accountService) UpdateAddress(ctx context.Context, a Address) (*Address, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.repository.UpdateAddress(ctx, a); err != nil {
+		return nil, err
+	}
+	return s.repository.GetAddressByID(ctx, a.ID)
+}
+
+func (s *accountService) DeleteAddress(ctx context.Context, id string) error {
+	return s.repository.DeleteAddress(ctx, id)
+}
+
+// Register creates an account that logs in with email and password and returns
+// it with its first tokens
+func (s *accountService) Register(ctx context.Context, name string, email string, password string, currency string) (*Account, *auth.Tokens, error) {
+	name, err := validateName(name)
+	if err != nil {
+		return nil, nil, err
+	}
+	if email, err = normalizeEmail(email); err != nil {
+		return nil, nil, err
+	}
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if currency, err = money.ParseCurrency(currency); err != nil {
+		return nil, nil, err
+	}
+	passwordHash, err := hashPassword(password)
+	if err != nil {
+		return nil, nil, err
+	}
+	a := &Account{
+		ID:       ksuid.New().String(),
+		Name:     name,
+		Currency: currency,
+		Email:    email,
+		Role:     auth.RoleCustomer,
+	}
+	if err := s.repository.PutAccount(ctx, *a, passwordHash); err != nil {
+		return nil, nil, err
+	}
+	tokens, err := s.tokens.Issue(a.ID, a.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, tokens, nil
+}
+
+// Login checks the password of the live account with the email. Unknown emails,
+// wrong passwords and deleted accounts all fail with ErrInvalidCredentials, so
+// callers cannot probe which emails are registered.
+func (s *accountService) Login(ctx context.Context, email string, password string) (*Account, *auth.Tokens, error) {
+	email, err := normalizeEmail(email)
+	if err != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+	a, passwordHash, err := s.repository.GetCredentials(ctx, email)
+	if errors.Is(err, ErrNotFound) {
+		bcrypt.CompareHashAndPassword(dummyHash, []byte(password))
+		return nil, nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(passwordHash), []byte(password)) != nil || a.DeletedAt != nil {
+		return nil, nil, ErrInvalidCredentials
+	}
+	tokens, err := s.tokens.Issue(a.ID, a.Role)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, tokens, nil
+}
+
+// RefreshToken exchanges a refresh token for a new pair of tokens, as long as the
+// account it was issued to has not been deleted since. The new tokens carry the
+// current role of the account.
+func (s *accountService) RefreshToken(ctx context.Context, refreshToken string) (*auth.Tokens, error) {
+	id, err := s.tokens.VerifyRefreshToken(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+	a, err := s.repository.GetAccountByID(ctx, id)
+	if errors.Is(err, ErrNotFound) || (err == nil && a.DeletedAt != nil) {
+		return nil, auth.ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.tokens.Issue(a.ID, a.Role)
+}
+
+// validateName trims the name and checks it fits the accounts table
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errs.InvalidArgument("account name must not be empty")
+	}
+	if utf8.RuneCountInString(name) > maxNameLength {
+		return "", errs.InvalidArgument(fmt.Sprintf("account name must be at most %d characters", maxNameLength))
 	}
-	return s.repository.ListAccounts(ctx, skip, take)
+	return name, nil
 }
@@ -1,4 +1,53 @@
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- The schema below includes every migration in migrations/
+INSERT INTO schema_migrations (version)
+    SELECT generate_series(1, 7)
+    ON CONFLICT DO NOTHING;
+
+-- Deleted accounts are kept so order history stays resolvable. Emails are stored
+-- lower-cased; password_hash is a bcrypt hash. Accounts created without
+-- credentials have neither. Admins are made by hand:
+-- UPDATE accounts SET role = 'admin' WHERE email = '...'
 CREATE TABLE IF NOT EXISTS accounts (
     id CHAR(27) PRIMARY KEY,
-    name VARCHAR(24) NOT NULL
-);
\ No newline at end of file
+    name VARCHAR(24) NOT NULL CHECK (btrim(name) <> ''),
+    deleted_at TIMESTAMP WITH TIME ZONE,
+    currency CHAR(3) NOT NULL DEFAULT 'USD',
+    email VARCHAR(254),
+    password_hash TEXT,
+    role VARCHAR(16) NOT NULL DEFAULT 'customer' CHECK (role IN ('customer', 'admin'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS accounts_email_idx ON accounts (email);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    caller VARCHAR(64) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    account_id CHAR(27) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (caller, key)
+);
+
+-- Shipping and billing addresses. Countries and subdivisions are ISO 3166 codes;
+-- the partial unique indexes allow one default address of each kind per account.
+CREATE TABLE IF NOT EXISTS addresses (
+    id CHAR(27) PRIMARY KEY,
+    account_id CHAR(27) NOT NULL REFERENCES accounts (id),
+    name VARCHAR(64) NOT NULL,
+    line1 VARCHAR(128) NOT NULL,
+    line2 VARCHAR(128) NOT NULL DEFAULT '',
+    city VARCHAR(64) NOT NULL,
+    subdivision VARCHAR(3) NOT NULL DEFAULT '',
+    postal_code VARCHAR(16) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL,
+    default_shipping BOOLEAN NOT NULL DEFAULT FALSE,
+    default_billing BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX IF NOT EXISTS addresses_account_id_idx ON addresses (account_id);
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_default_shipping_idx ON addresses (account_id) WHERE default_shipping;
+CREATE UNIQUE INDEX IF NOT EXISTS addresses_default_billing_idx ON addresses (account_id) WHERE default_billing;
@@ -0,0 +1,241 @@
+// Package auth identifies the callers of the services and decides what they may
+// do. The gateway forwards the access token of the user with every call it
+// makes, and services call each other with service tokens; each service checks
+// the token of an incoming call against the Policy of its methods.
+package auth
+
+import (
+	"context"
+	"log"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Role of a caller. Accounts are customers unless made admins; RoleService is
+// only given to services.
+type Role string
+
+const (
+	RoleCustomer Role = "customer"
+	RoleAdmin    Role = "admin"
+	RoleService  Role = "service"
+)
+
+func (r Role) valid() bool {
+	return r == RoleCustomer || r == RoleAdmin || r == RoleService
+}
+
+var (
+	ErrUnauthenticated  = errs.Unauthenticated("authentication required")
+	ErrPermissionDenied = errs.PermissionDenied("permission denied")
+)
+
+// Identity is the verified caller of a request: an account, or a service if
+// Role is RoleService
+type Identity struct {
+	AccountID string
+	Service   string
+	Role      Role
+}
+
+// Privileged reports whether the caller may act on any account
+func (i *Identity) Privileged() bool {
+	return i.Role == RoleAdmin || i.Role == RoleService
+}
+
+// Name returns the account ID of the caller, or the name of the service for
+// service tokens
+func (i *Identity) Name() string {
+	if i.Role == RoleService {
+		return i.Service
+	}
+	return i.AccountID
+}
+
+type contextKey int
+
+const (
+	identityKey contextKey = iota
+	tokenKey
+)
+
+// NewContext returns a copy of ctx carrying the identity of the caller
+func NewContext(ctx context.Context, identity *Identity) context.Context {
+	return context.WithValue(ctx, identityKey, identity)
+}
+
+// FromContext returns the identity of the caller, or nil for anonymous calls
+func FromContext(ctx context.Context) *Identity {
+	identity, _ := ctx.Value(identityKey).(*Identity)
+	return identity
+}
+
+// Caller returns the name of the caller, or an empty string for anonymous calls
+func Caller(ctx context.Context) string {
+	if identity := FromContext(ctx); identity != nil {
+		return identity.Name()
+	}
+	return ""
+}
+
+// CheckAccount returns nil if the caller may read and change the account with
+// the given ID: admins and services may act on any account, customers only on
+// their own
+func CheckAccount(ctx context.Context, accountID string) error {
+	identity := FromContext(ctx)
+	if identity == nil {
+		return ErrUnauthenticated
+	}
+	if identity.Privileged() || identity.AccountID == accountID {
+		return nil
+	}
+	return ErrPermissionDenied
+}
+
+// TokenSource returns the bearer token to send with an outgoing call, or an
+// empty string to call anonymously
+type TokenSource func(ctx context.Context) (string, error)
+
+// WithToken returns a copy of ctx carrying the token of the caller, which
+// CallerTokens forwards
+func WithToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, tokenKey, token)
+}
+
+// CallerTokens forwards the token stored in the context with WithToken. The
+// gateway calls the services with it, so they act with the user's permissions.
+func CallerTokens(ctx context.Context) (string, error) {
+	token, _ := ctx.Value(tokenKey).(string)
+	return token, nil
+}
+
+// Access is the kind of caller a method is open to
+type Access int
+
+const (
+	// Public methods may be called anonymously
+	Public Access = iota
+	// Customer methods need an authenticated caller; the handler checks the
+	// caller owns what it acts on with CheckAccount
+	Customer
+	// Admin methods may only be called by admins and services
+	Admin
+	// Internal methods may only be called by services
+	Internal
+)
+
+// Policy maps the full gRPC method names of a service, e.g.
+// "/pb.AccountService/GetAccount", to who may call them. Methods missing from
+// the policy are denied.
+type Policy map[string]Access
+
+// UnaryServerInterceptor verifies the bearer token of incoming calls, checks the
+// caller against the policy and puts its identity in the handler's context. A
+// call with an invalid token is rejected even if the method is public.
+func UnaryServerInterceptor(tokens *TokenManager, policy Policy) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		identity, err := authorize(ctx, tokens, policy, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		if identity != nil {
+			ctx = NewContext(ctx, identity)
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor is the UnaryServerInterceptor of streaming calls; the
+// caller is checked once, when the stream opens
+func StreamServerInterceptor(tokens *TokenManager, policy Policy) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		identity, err := authorize(ss.Context(), tokens, policy, info.FullMethod)
+		if err != nil {
+			return err
+		}
+		if identity != nil {
+			ss = &serverStream{ServerStream: ss, ctx: NewContext(ss.Context(), identity)}
+		}
+		return handler(srv, ss)
+	}
+}
+
+// serverStream passes the identity of the caller to streaming handlers
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// authorize checks the caller of method against the policy and returns its
+// identity, or nil for anonymous callers of public methods
+func authorize(ctx context.Context, tokens *TokenManager, policy Policy, method string) (*Identity, error) {
+	access, ok := policy[method]
+	if !ok {
+		log.Println("No access policy for", method)
+		return nil, ErrPermissionDenied
+	}
+	identity, err := identify(ctx, tokens)
+	if err != nil {
+		return nil, err
+	}
+	if identity == nil {
+		if access != Public {
+			return nil, ErrUnauthenticated
+		}
+		return nil, nil
+	}
+	if (access == Admin && !identity.Privileged()) || (access == Internal && identity.Role != RoleService) {
+		return nil, ErrPermissionDenied
+	}
+	return identity, nil
+}
+
+// UnaryClientInterceptor sends the token of the source with every call
+func UnaryClientInterceptor(tokens TokenSource) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		token, err := tokens(ctx)
+		if err != nil {
+			return err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor sends the token of the source with every streaming call
+func StreamClientInterceptor(tokens TokenSource) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		token, err := tokens(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// identify returns the identity of the bearer token of the call, or nil if it
+// has none
+func identify(ctx context.Context, tokens *TokenManager) (*Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, nil
+	}
+	token, ok := strings.CutPrefix(values[0], "Bearer ")
+	if !ok {
+		return nil, ErrInvalidToken
+	}
+	return tokens.VerifyAccessToken(token)
+}
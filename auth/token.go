@@ -0,0 +1,148 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sdshah09/GoCore/errs"
+)
+
+const (
+	tokenIssuer      = "gocore-account"
+	accessTokenType  = "access"
+	refreshTokenType = "refresh"
+	// serviceTokenTTL is the lifetime of the tokens services call each other
+	// with; they are renewed a minute before they expire
+	serviceTokenTTL = time.Hour
+)
+
+var ErrInvalidToken = errs.Unauthenticated("invalid or expired token")
+
+// Tokens are issued to an account on Register, Login and RefreshToken. The access
+// token authenticates requests until ExpiresAt; the refresh token, which lives
+// longer, is exchanged for a new pair.
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	ExpiresAt    time.Time
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	// Type tells access tokens from refresh tokens, so one cannot be used as the other
+	Type string `json:"typ"`
+	Role Role   `json:"role"`
+}
+
+// TokenManager issues and verifies HS256 signed JWTs. The subject of a token is
+// the account ID, or the service name for service tokens. Every service and the
+// gateway share its key.
+type TokenManager struct {
+	key        []byte
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+func NewTokenManager(key []byte, accessTTL time.Duration, refreshTTL time.Duration) (*TokenManager, error) {
+	if len(key) < 32 {
+		return nil, errors.New("token signing key must be at least 32 bytes")
+	}
+	return &TokenManager{key, accessTTL, refreshTTL}, nil
+}
+
+// Issue returns a new access and refresh token for the account
+func (m *TokenManager) Issue(accountID string, role Role) (*Tokens, error) {
+	now := time.Now()
+	access, err := m.sign(accountID, role, accessTokenType, now, m.accessTTL)
+	if err != nil {
+		return nil, err
+	}
+	refresh, err := m.sign(accountID, role, refreshTokenType, now, m.refreshTTL)
+	if err != nil {
+		return nil, err
+	}
+	return &Tokens{
+		AccessToken:  access,
+		RefreshToken: refresh,
+		ExpiresAt:    now.Add(m.accessTTL),
+	}, nil
+}
+
+// VerifyAccessToken returns the identity an access token was issued to
+func (m *TokenManager) VerifyAccessToken(token string) (*Identity, error) {
+	c, err := m.verify(token, accessTokenType)
+	if err != nil {
+		return nil, err
+	}
+	identity := &Identity{Role: c.Role}
+	if c.Role == RoleService {
+		identity.Service = c.Subject
+	} else {
+		identity.AccountID = c.Subject
+	}
+	return identity, nil
+}
+
+// VerifyRefreshToken returns the account ID a refresh token was issued to. The
+// role is not returned, it is read again when the tokens are refreshed.
+func (m *TokenManager) VerifyRefreshToken(token string) (string, error) {
+	c, err := m.verify(token, refreshTokenType)
+	if err != nil {
+		return "", err
+	}
+	return c.Subject, nil
+}
+
+// ServiceTokens returns a TokenSource of access tokens that identify the service
+// with the given name to the other services
+func (m *TokenManager) ServiceTokens(name string) TokenSource {
+	var mu sync.Mutex
+	var token string
+	var expiresAt time.Time
+	return func(ctx context.Context) (string, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		now := time.Now()
+		if now.Add(time.Minute).Before(expiresAt) {
+			return token, nil
+		}
+		t, err := m.sign(name, RoleService, accessTokenType, now, serviceTokenTTL)
+		if err != nil {
+			return "", err
+		}
+		token, expiresAt = t, now.Add(serviceTokenTTL)
+		return token, nil
+	}
+}
+
+func (m *TokenManager) sign(subject string, role Role, tokenType string, now time.Time, ttl time.Duration) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    tokenIssuer,
+			Subject:   subject,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Type: tokenType,
+		Role: role,
+	}).SignedString(m.key)
+}
+
+func (m *TokenManager) verify(token string, tokenType string) (*claims, error) {
+	c := &claims{}
+	_, err := jwt.ParseWithClaims(
+		token,
+		c,
+		func(*jwt.Token) (interface{}, error) { return m.key, nil },
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(tokenIssuer),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || c.Type != tokenType || c.Subject == "" || !c.Role.valid() {
+		return nil, ErrInvalidToken
+	}
+	return c, nil
+}
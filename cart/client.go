@@ -0,0 +1,122 @@
+package cart
+
+import (
+	"context"
+
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/cart/pb"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+type Client struct {
+	conn    *grpc.ClientConn
+	service pb.CartServiceClient
+}
+
+// NewClient connects to the cart service at url and authenticates every call
+// with a token from tokens
+func NewClient(url string, tokens auth.TokenSource) (*Client, error) {
+	conn, err := grpc.NewClient(
+		url,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(errs.UnaryClientInterceptor, auth.UnaryClientInterceptor(tokens)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	service := pb.NewCartServiceClient(conn)
+	return &Client{conn, service}, nil
+}
+
+func (client *Client) Close() {
+	client.conn.Close()
+}
+
+func (client *Client) GetCart(ctx context.Context, accountID string) (*Cart, error) {
+	res, err := client.service.GetCart(ctx, &pb.GetCartRequest{AccountId: accountID})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(res.Cart), nil
+}
+
+func (client *Client) AddItem(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error) {
+	res, err := client.service.AddItem(ctx, &pb.AddItemRequest{
+		AccountId: accountID,
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(res.Cart), nil
+}
+
+func (client *Client) UpdateItemQuantity(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error) {
+	res, err := client.service.UpdateItemQuantity(ctx, &pb.UpdateItemQuantityRequest{
+		AccountId: accountID,
+		ProductId: productID,
+		Quantity:  quantity,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(res.Cart), nil
+}
+
+func (client *Client) RemoveItem(ctx context.Context, accountID string, productID string) (*Cart, error) {
+	res, err := client.service.RemoveItem(ctx, &pb.RemoveItemRequest{
+		AccountId: accountID,
+		ProductId: productID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cartFromProto(res.Cart), nil
+}
+
+func (client *Client) ClearCart(ctx context.Context, accountID string) error {
+	_, err := client.service.ClearCart(ctx, &pb.ClearCartRequest{AccountId: accountID})
+	return err
+}
+
+// Checkout places an order for the items in the cart and returns its ID
+func (client *Client) Checkout(ctx context.Context, accountID string, region string, addressID string, couponCode string, idempotencyKey string) (string, error) {
+	res, err := client.service.Checkout(ctx, &pb.CheckoutRequest{
+		AccountId:      accountID,
+		Region:         region,
+		AddressId:      addressID,
+		CouponCode:     couponCode,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.OrderId, nil
+}
+
+func cartFromProto(cartProto *pb.Cart) *Cart {
+	c := &Cart{
+		AccountID: cartProto.AccountId,
+		Items:     []Item{},
+	}
+	for _, item := range cartProto.Items {
+		c.Items = append(c.Items, Item{
+			ProductID:    item.ProductId,
+			Name:         item.Name,
+			Quantity:     item.Quantity,
+			Price:        moneyFromProto(item.Price),
+			AddedAt:      item.AddedAt.AsTime(),
+			PriceChanged: item.PriceChanged,
+			Available:    item.Available,
+		})
+	}
+	return c
+}
+
+func moneyFromProto(m *pb.Money) money.Money {
+	return money.Money{Amount: m.GetAmount(), Currency: m.GetCurrency()}
+}
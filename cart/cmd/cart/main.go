@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/cart"
+	"github.com/sdshah09/GoCore/order"
+	"github.com/sdshah09/GoCore/product"
+	"github.com/tinrab/retry"
+)
+
+type Config struct {
+	DBHost     string `envconfig:"DB_HOST"`
+	DBPort     string `envconfig:"DB_PORT"`
+	DBName     string `envconfig:"DB_NAME"`
+	DBUser     string `envconfig:"DB_USER"`
+	DBPassword string `envconfig:"DB_PASSWORD"`
+	AccountURL string `envconfig:"ACCOUNT_SERVICE_URL"`
+	ProductURL string `envconfig:"PRODUCT_SERVICE_URL"`
+	OrderURL   string `envconfig:"ORDER_SERVICE_URL"`
+	HealthPort int    `envconfig:"HEALTH_PORT" default:"8080"`
+	// Repository selects the storage backend: "postgres" (default) or "memory"
+	Repository string `envconfig:"REPOSITORY" default:"postgres"`
+	// JWTSecret verifies the access tokens of callers and signs the tokens the
+	// service calls other services with
+	JWTSecret string `envconfig:"JWT_SECRET" required:"true"`
+}
+
+func (c Config) DatabaseURL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		c.DBUser,
+		url.QueryEscape(c.DBPassword),
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+	)
+}
+
+func main() {
+	var cfg Config
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	var repo cart.Repository
+	if cfg.Repository == "memory" {
+		log.Println("Using in-memory repository, data is lost on restart")
+		repo = cart.NewMemoryRepository()
+	} else {
+		retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+			repo, err = cart.NewPostgresRepository(cfg.DatabaseURL())
+			if err != nil {
+				log.Println(err)
+			}
+			return
+		})
+	}
+	defer repo.Close()
+
+	// Start HTTP health check server
+	go func() {
+		http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("OK"))
+		})
+		http.HandleFunc("/ready", func(w http.ResponseWriter, r *http.Request) {
+			// Check database connectivity
+			if err := repo.Ping(); err != nil {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte("Database not available"))
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("OK"))
+		})
+		log.Printf("Health check server listening on :%d", cfg.HealthPort)
+		log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", cfg.HealthPort), nil))
+	}()
+
+	tokens, err := auth.NewTokenManager([]byte(cfg.JWTSecret), 0, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	serviceTokens := tokens.ServiceTokens("cart")
+	accountClient, err := account.NewClient(cfg.AccountURL, serviceTokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer accountClient.Close()
+	productClient, err := product.NewClient(cfg.ProductURL, serviceTokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer productClient.Close()
+	orderClient, err := order.NewClient(cfg.OrderURL, serviceTokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer orderClient.Close()
+
+	log.Println("Listening on 8084...")
+	s := cart.NewService(repo, productClient, orderClient)
+	log.Fatal(cart.ListenGRPC(s, tokens, accountClient, 8084))
+}
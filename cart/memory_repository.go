@@ -0,0 +1,105 @@
+package cart
+
+import (
+	"context"
+	"sync"
+)
+
+// memoryRepository keeps carts in process memory. It is meant for tests and
+// local demos; nothing survives a restart.
+type memoryRepository struct {
+	mu    sync.RWMutex
+	carts map[string][]Item
+}
+
+func NewMemoryRepository() Repository {
+	return &memoryRepository{
+		carts: map[string][]Item{},
+	}
+}
+
+func (r *memoryRepository) Close() error {
+	return nil
+}
+
+func (r *memoryRepository) Ping() error {
+	return nil
+}
+
+func (r *memoryRepository) GetCart(ctx context.Context, accountID string) (*Cart, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	items := append([]Item{}, r.carts[accountID]...)
+	return &Cart{AccountID: accountID, Items: items}, nil
+}
+
+func (r *memoryRepository) AddItem(ctx context.Context, accountID string, item Item) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := r.carts[accountID]
+	if i := indexOf(items, item.ProductID); i >= 0 {
+		if items[i].Quantity+item.Quantity > maxQuantity {
+			return ErrInvalidQuantity
+		}
+		items[i].Quantity += item.Quantity
+		items[i].Name = item.Name
+		items[i].Price = item.Price
+		return nil
+	}
+	r.carts[accountID] = append(items, item)
+	return nil
+}
+
+func (r *memoryRepository) SetItemQuantity(ctx context.Context, accountID string, productID string, quantity uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := r.carts[accountID]
+	i := indexOf(items, productID)
+	if i < 0 {
+		return ErrItemNotFound
+	}
+	items[i].Quantity = quantity
+	return nil
+}
+
+func (r *memoryRepository) UpdateItems(ctx context.Context, accountID string, updated []Item) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := r.carts[accountID]
+	for _, item := range updated {
+		if i := indexOf(items, item.ProductID); i >= 0 {
+			items[i].Name = item.Name
+			items[i].Price = item.Price
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) RemoveItem(ctx context.Context, accountID string, productID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	items := r.carts[accountID]
+	i := indexOf(items, productID)
+	if i < 0 {
+		return ErrItemNotFound
+	}
+	r.carts[accountID] = append(items[:i:i], items[i+1:]...)
+	return nil
+}
+
+func (r *memoryRepository) ClearCart(ctx context.Context, accountID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.carts, accountID)
+	return nil
+}
+
+// indexOf returns the index of the item for productID, or -1
+func indexOf(items []Item, productID string) int {
+	for i, item := range items {
+		if item.ProductID == productID {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,191 @@
+package cart
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/sdshah09/GoCore/errs"
+)
+
+var ErrItemNotFound = errs.NotFound("product is not in the cart")
+
+// pqCheckViolation is the Postgres error code of a failed CHECK constraint, see
+// https://www.postgresql.org/docs/current/errcodes-appendix.html
+const pqCheckViolation = "23514"
+
+type Repository interface {
+	Close() error
+	Ping() error
+	// GetCart returns an empty cart for accounts that have no items
+	GetCart(ctx context.Context, accountID string) (*Cart, error)
+	// AddItem stores item, adding its quantity to that of the same product
+	// already in the cart
+	AddItem(ctx context.Context, accountID string, item Item) error
+	SetItemQuantity(ctx context.Context, accountID string, productID string, quantity uint32) error
+	// UpdateItems stores the name and price of items
+	UpdateItems(ctx context.Context, accountID string, items []Item) error
+	RemoveItem(ctx context.Context, accountID string, productID string) error
+	ClearCart(ctx context.Context, accountID string) error
+}
+
+type postgresRepository struct {
+	db *sql.DB
+}
+
+func NewPostgresRepository(url string) (Repository, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+
+	return &postgresRepository{db}, nil
+}
+
+func (r *postgresRepository) Ping() error {
+	return r.db.Ping()
+}
+
+func (r *postgresRepository) Close() error {
+	r.db.Close()
+	return nil
+}
+
+func (r *postgresRepository) GetCart(ctx context.Context, accountID string) (*Cart, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT product_id, name, quantity, price_amount, currency, added_at
+    FROM cart_items
+    WHERE account_id = $1
+    ORDER BY added_at, product_id`,
+		accountID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cart := &Cart{AccountID: accountID, Items: []Item{}}
+	for rows.Next() {
+		item := Item{}
+		if err = rows.Scan(
+			&item.ProductID,
+			&item.Name,
+			&item.Quantity,
+			&item.Price.Amount,
+			&item.Price.Currency,
+			&item.AddedAt,
+		); err != nil {
+			return nil, err
+		}
+		cart.Items = append(cart.Items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+func (r *postgresRepository) AddItem(ctx context.Context, accountID string, item Item) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO cart_items(account_id, product_id, name, quantity, price_amount, currency, added_at)
+    VALUES ($1, $2, $3, $4, $5, $6, $7)
+    ON CONFLICT (account_id, product_id) DO UPDATE SET
+      quantity = cart_items.quantity + EXCLUDED.quantity,
+      name = EXCLUDED.name,
+      price_amount = EXCLUDED.price_amount,
+      currency = EXCLUDED.currency`,
+		accountID,
+		item.ProductID,
+		item.Name,
+		item.Quantity,
+		item.Price.Amount,
+		item.Price.Currency,
+		item.AddedAt,
+	)
+	return translateError(err)
+}
+
+func (r *postgresRepository) SetItemQuantity(ctx context.Context, accountID string, productID string, quantity uint32) error {
+	res, err := r.db.ExecContext(
+		ctx,
+		"UPDATE cart_items SET quantity = $3 WHERE account_id = $1 AND product_id = $2",
+		accountID,
+		productID,
+		quantity,
+	)
+	if err != nil {
+		return translateError(err)
+	}
+	return requireAffected(res)
+}
+
+func (r *postgresRepository) UpdateItems(ctx context.Context, accountID string, items []Item) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+	for _, item := range items {
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE cart_items SET name = $3, price_amount = $4, currency = $5 WHERE account_id = $1 AND product_id = $2",
+			accountID,
+			item.ProductID,
+			item.Name,
+			item.Price.Amount,
+			item.Price.Currency,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *postgresRepository) RemoveItem(ctx context.Context, accountID string, productID string) error {
+	res, err := r.db.ExecContext(ctx, "DELETE FROM cart_items WHERE account_id = $1 AND product_id = $2", accountID, productID)
+	if err != nil {
+		return err
+	}
+	return requireAffected(res)
+}
+
+func (r *postgresRepository) ClearCart(ctx context.Context, accountID string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM cart_items WHERE account_id = $1", accountID)
+	return err
+}
+
+// translateError turns the quantity constraint violation into ErrInvalidQuantity,
+// e.g. when adding to an item pushes it over maxQuantity
+func translateError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqCheckViolation {
+		return ErrInvalidQuantity
+	}
+	return err
+}
+
+// requireAffected returns ErrItemNotFound if the statement did not touch any item
+func requireAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrItemNotFound
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package cart
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+
+	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/cart/pb"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type grpcServer struct {
+	pb.UnimplementedCartServiceServer
+	service       Service
+	accountClient *account.Client
+}
+
+// policy lets customers use their own cart, checked in the handlers
+var policy = auth.Policy{
+	pb.CartService_GetCart_FullMethodName:            auth.Customer,
+	pb.CartService_AddItem_FullMethodName:            auth.Customer,
+	pb.CartService_UpdateItemQuantity_FullMethodName: auth.Customer,
+	pb.CartService_RemoveItem_FullMethodName:         auth.Customer,
+	pb.CartService_ClearCart_FullMethodName:          auth.Customer,
+	pb.CartService_Checkout_FullMethodName:           auth.Customer,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, accountClient *account.Client, port int) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		return err
+	}
+	serv := grpc.NewServer(grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor, auth.UnaryServerInterceptor(tokens, policy)))
+	pb.RegisterCartServiceServer(serv, &grpcServer{
+		service:       s,
+		accountClient: accountClient,
+	})
+	return serv.Serve(lis)
+}
+
+func (server *grpcServer) GetCart(ctx context.Context, r *pb.GetCartRequest) (*pb.GetCartResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	c, err := server.service.GetCart(ctx, r.AccountId)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	cartProto, err := cartToProto(c)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetCartResponse{Cart: cartProto}, nil
+}
+
+// AddItem only accepts items for live accounts, so carts are not created for
+// accounts that do not exist
+func (server *grpcServer) AddItem(ctx context.Context, r *pb.AddItemRequest) (*pb.AddItemResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	a, err := server.accountClient.GetAccount(ctx, r.AccountId)
+	if err != nil {
+		log.Println("Error getting account: ", err)
+		return nil, err
+	}
+	if a.DeletedAt != nil {
+		return nil, account.ErrNotFound
+	}
+	c, err := server.service.AddItem(ctx, r.AccountId, r.ProductId, r.Quantity)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	cartProto, err := cartToProto(c)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AddItemResponse{Cart: cartProto}, nil
+}
+
+func (server *grpcServer) UpdateItemQuantity(ctx context.Context, r *pb.UpdateItemQuantityRequest) (*pb.UpdateItemQuantityResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	c, err := server.service.UpdateItemQuantity(ctx, r.AccountId, r.ProductId, r.Quantity)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	cartProto, err := cartToProto(c)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.UpdateItemQuantityResponse{Cart: cartProto}, nil
+}
+
+func (server *grpcServer) RemoveItem(ctx context.Context, r *pb.RemoveItemRequest) (*pb.RemoveItemResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	c, err := server.service.RemoveItem(ctx, r.AccountId, r.ProductId)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	cartProto, err := cartToProto(c)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RemoveItemResponse{Cart: cartProto}, nil
+}
+
+func (server *grpcServer) ClearCart(ctx context.Context, r *pb.ClearCartRequest) (*pb.ClearCartResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	if err := server.service.ClearCart(ctx, r.AccountId); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.ClearCartResponse{}, nil
+}
+
+func (server *grpcServer) Checkout(ctx context.Context, r *pb.CheckoutRequest) (*pb.CheckoutResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	o, err := server.service.Checkout(ctx, r.AccountId, r.Region, r.AddressId, r.CouponCode, r.IdempotencyKey)
+	if err != nil {
+		log.Println("Error checking out: ", err)
+		return nil, err
+	}
+	return &pb.CheckoutResponse{OrderId: o.ID}, nil
+}
+
+func cartToProto(c *Cart) (*pb.Cart, error) {
+	totals, err := c.Totals()
+	if err != nil {
+		return nil, err
+	}
+	cartProto := &pb.Cart{
+		AccountId: c.AccountID,
+		Items:     []*pb.CartItem{},
+		Totals:    []*pb.Money{},
+	}
+	for _, item := range c.Items {
+		cartProto.Items = append(cartProto.Items, &pb.CartItem{
+			ProductId:    item.ProductID,
+			Name:         item.Name,
+			Quantity:     item.Quantity,
+			Price:        moneyToProto(item.Price),
+			PriceChanged: item.PriceChanged,
+			Available:    item.Available,
+			AddedAt:      timestamppb.New(item.AddedAt),
+		})
+	}
+	for _, total := range totals {
+		cartProto.Totals = append(cartProto.Totals, moneyToProto(total))
+	}
+	return cartProto, nil
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
@@ -0,0 +1,248 @@
+package cart
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+	"github.com/sdshah09/GoCore/order"
+	"github.com/sdshah09/GoCore/product"
+)
+
+// maxQuantity bounds the quantity of a single cart item, matching the CHECK
+// constraint in up.sql
+const maxQuantity = 1000
+
+var (
+	ErrInvalidQuantity = errs.InvalidArgument(fmt.Sprintf("quantity must be between 1 and %d", maxQuantity))
+	ErrEmptyCart       = errs.FailedPrecondition("cart is empty")
+	ErrPricesChanged   = errs.FailedPrecondition("prices in the cart changed, review the cart and check out again")
+	ErrItemUnavailable = errs.FailedPrecondition("cart contains products that are no longer available")
+)
+
+// Item is a product in a cart. Price is the unit price in the currency of the
+// product, as last checked against the catalog.
+type Item struct {
+	ProductID string
+	Name      string
+	Quantity  uint32
+	Price     money.Money
+	AddedAt   time.Time
+	// PriceChanged and Available are set when the cart is checked against the
+	// catalog and are not stored
+	PriceChanged bool
+	Available    bool
+}
+
+// Cart holds the items an account intends to order
+type Cart struct {
+	AccountID string
+	Items     []Item
+}
+
+// Totals sums the item prices per currency, in the order the currencies first
+// appear in the cart. Products priced in different currencies are only
+// converted into one currency when the cart is checked out.
+func (c *Cart) Totals() ([]money.Money, error) {
+	totals := []money.Money{}
+	index := map[string]int{}
+	for _, item := range c.Items {
+		linePrice, err := item.Price.Multiply(int64(item.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		i, ok := index[linePrice.Currency]
+		if !ok {
+			index[linePrice.Currency] = len(totals)
+			totals = append(totals, linePrice)
+			continue
+		}
+		if totals[i], err = totals[i].Add(linePrice); err != nil {
+			return nil, err
+		}
+	}
+	return totals, nil
+}
+
+// Catalog looks up the current products; it is implemented by the product
+// service client
+type Catalog interface {
+	GetProduct(ctx context.Context, id string) (*product.Product, error)
+	GetProducts(ctx context.Context, query string, ids []string, skip uint64, take uint64) ([]product.Product, error)
+}
+
+// Orders places orders; it is implemented by the order service client
+type Orders interface {
+	PostOrder(ctx context.Context, accountID string, region string, addressID string, products []order.OrderedProduct, couponCode string, idempotencyKey string) (*order.Order, error)
+	GetOrderByIdempotencyKey(ctx context.Context, accountID string, idempotencyKey string) (*order.Order, error)
+}
+
+type Service interface {
+	GetCart(ctx context.Context, accountID string) (*Cart, error)
+	AddItem(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error)
+	UpdateItemQuantity(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error)
+	RemoveItem(ctx context.Context, accountID string, productID string) (*Cart, error)
+	ClearCart(ctx context.Context, accountID string) error
+	Checkout(ctx context.Context, accountID string, region string, addressID string, couponCode string, idempotencyKey string) (*order.Order, error)
+}
+
+type cartService struct {
+	repository Repository
+	catalog    Catalog
+	orders     Orders
+}
+
+func NewService(r Repository, catalog Catalog, orders Orders) Service {
+	return &cartService{r, catalog, orders}
+}
+
+// GetCart returns the cart of the account, with its prices checked against the
+// catalog
+func (s *cartService) GetCart(ctx context.Context, accountID string) (*Cart, error) {
+	cart, err := s.repository.GetCart(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.revalidate(ctx, cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// AddItem puts quantity units of the product in the cart, adding to the
+// quantity already there
+func (s *cartService) AddItem(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error) {
+	if quantity == 0 || quantity > maxQuantity {
+		return nil, ErrInvalidQuantity
+	}
+	p, err := s.catalog.GetProduct(ctx, productID)
+	if err != nil {
+		return nil, err
+	}
+	item := Item{
+		ProductID: p.ID,
+		Name:      p.Name,
+		Quantity:  quantity,
+		Price:     p.Price,
+		AddedAt:   time.Now(),
+	}
+	if err := s.repository.AddItem(ctx, accountID, item); err != nil {
+		return nil, err
+	}
+	return s.GetCart(ctx, accountID)
+}
+
+// UpdateItemQuantity sets the quantity of an item in the cart; a quantity of 0
+// removes the item
+func (s *cartService) UpdateItemQuantity(ctx context.Context, accountID string, productID string, quantity uint32) (*Cart, error) {
+	if quantity == 0 {
+		return s.RemoveItem(ctx, accountID, productID)
+	}
+	if quantity > maxQuantity {
+		return nil, ErrInvalidQuantity
+	}
+	if err := s.repository.SetItemQuantity(ctx, accountID, productID, quantity); err != nil {
+		return nil, err
+	}
+	return s.GetCart(ctx, accountID)
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, accountID string, productID string) (*Cart, error) {
+	if err := s.repository.RemoveItem(ctx, accountID, productID); err != nil {
+		return nil, err
+	}
+	return s.GetCart(ctx, accountID)
+}
+
+func (s *cartService) ClearCart(ctx context.Context, accountID string) error {
+	return s.repository.ClearCart(ctx, accountID)
+}
+
+// Checkout places an order for the items in the cart and empties it. The prices
+// are checked against the catalog first: if any changed since the cart was last
+// read, the cart is updated and ErrPricesChanged returned, so the customer never
+// pays a price they have not seen. A retry with the idempotency key of a checkout
+// that placed its order returns that order, although the cart was emptied.
+func (s *cartService) Checkout(ctx context.Context, accountID string, region string, addressID string, couponCode string, idempotencyKey string) (*order.Order, error) {
+	if idempotencyKey != "" {
+		o, err := s.orders.GetOrderByIdempotencyKey(ctx, accountID, idempotencyKey)
+		if err == nil {
+			return o, nil
+		}
+		if !errors.Is(err, errs.ErrNotFound) {
+			return nil, err
+		}
+	}
+	cart, err := s.repository.GetCart(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, ErrEmptyCart
+	}
+	if err := s.revalidate(ctx, cart); err != nil {
+		return nil, err
+	}
+	products := []order.OrderedProduct{}
+	for _, item := range cart.Items {
+		if !item.Available {
+			return nil, fmt.Errorf("%w: %s", ErrItemUnavailable, item.Name)
+		}
+		if item.PriceChanged {
+			return nil, ErrPricesChanged
+		}
+		products = append(products, order.OrderedProduct{ID: item.ProductID, Quantity: item.Quantity})
+	}
+	o, err := s.orders.PostOrder(ctx, accountID, region, addressID, products, couponCode, idempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	// The order is placed at this point, so a failure to empty the cart must not
+	// fail the checkout
+	if err := s.repository.ClearCart(ctx, accountID); err != nil {
+		log.Println("Error clearing cart after checkout: ", err)
+	}
+	return o, nil
+}
+
+// revalidate checks the items of cart against the catalog. Items whose price or
+// name changed are updated in the repository, and flagged with PriceChanged if
+// it was the price; items no longer in the catalog are flagged as unavailable.
+func (s *cartService) revalidate(ctx context.Context, cart *Cart) error {
+	if len(cart.Items) == 0 {
+		return nil
+	}
+	ids := []string{}
+	for _, item := range cart.Items {
+		ids = append(ids, item.ProductID)
+	}
+	products, err := s.catalog.GetProducts(ctx, "", ids, 0, 0)
+	if err != nil {
+		return err
+	}
+	current := map[string]product.Product{}
+	for _, p := range products {
+		current[p.ID] = p
+	}
+	updated := []Item{}
+	for i := range cart.Items {
+		item := &cart.Items[i]
+		p, ok := current[item.ProductID]
+		item.Available = ok
+		if !ok || (p.Price == item.Price && p.Name == item.Name) {
+			continue
+		}
+		item.PriceChanged = p.Price != item.Price
+		item.Price = p.Price
+		item.Name = p.Name
+		updated = append(updated, *item)
+	}
+	if len(updated) == 0 {
+		return nil
+	}
+	return s.repository.UpdateItems(ctx, cart.AccountID, updated)
+}
@@ -0,0 +1,13 @@
+-- Items in the shopping cart of each account. Prices are in the currency of the
+-- product, as last checked against the catalog; an account without items has an
+-- empty cart.
+CREATE TABLE IF NOT EXISTS cart_items (
+    account_id CHAR(27) NOT NULL,
+    product_id CHAR(27) NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    quantity INT NOT NULL CHECK (quantity BETWEEN 1 AND 1000),
+    price_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    added_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (account_id, product_id)
+);
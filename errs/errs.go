@@ -0,0 +1,193 @@
+// Package errs defines the domain errors shared by all services and maps them
+// to and from gRPC status codes, so callers can tell a missing resource from a
+// bad request or an unavailable dependency.
+package errs
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Kinds of domain errors. Use errors.Is(err, errs.ErrNotFound) to check the kind
+// of an error, whether it was created locally or received from another service.
+var (
+	ErrNotFound           = errors.New("not found")
+	ErrAlreadyExists      = errors.New("already exists")
+	ErrInvalidArgument    = errors.New("invalid argument")
+	ErrFailedPrecondition = errors.New("failed precondition")
+	ErrUnavailable        = errors.New("unavailable")
+	ErrUnauthenticated    = errors.New("unauthenticated")
+	ErrPermissionDenied   = errors.New("permission denied")
+)
+
+var kindCodes = map[error]codes.Code{
+	ErrNotFound:           codes.NotFound,
+	ErrAlreadyExists:      codes.AlreadyExists,
+	ErrInvalidArgument:    codes.InvalidArgument,
+	ErrFailedPrecondition: codes.FailedPrecondition,
+	ErrUnavailable:        codes.Unavailable,
+	ErrUnauthenticated:    codes.Unauthenticated,
+	ErrPermissionDenied:   codes.PermissionDenied,
+}
+
+// Error is a domain error of a given kind with a caller facing message
+type Error struct {
+	kind    error
+	message string
+	// status is the status the error was received as, if it came from another service
+	status *status.Status
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Unwrap() error {
+	return e.kind
+}
+
+// GRPCStatus lets the grpc package send the error with the matching status code
+func (e *Error) GRPCStatus() *status.Status {
+	if e.status != nil {
+		return e.status
+	}
+	return status.New(kindCodes[e.kind], e.message)
+}
+
+func NotFound(message string) error {
+	return &Error{kind: ErrNotFound, message: message}
+}
+
+func AlreadyExists(message string) error {
+	return &Error{kind: ErrAlreadyExists, message: message}
+}
+
+func InvalidArgument(message string) error {
+	return &Error{kind: ErrInvalidArgument, message: message}
+}
+
+func FailedPrecondition(message string) error {
+	return &Error{kind: ErrFailedPrecondition, message: message}
+}
+
+func Unavailable(message string) error {
+	return &Error{kind: ErrUnavailable, message: message}
+}
+
+// Unauthenticated is returned when the caller's credentials are missing or invalid
+func Unauthenticated(message string) error {
+	return &Error{kind: ErrUnauthenticated, message: message}
+}
+
+// PermissionDenied is returned when the caller is authenticated but may not do
+// what it asked for
+func PermissionDenied(message string) error {
+	return &Error{kind: ErrPermissionDenied, message: message}
+}
+
+// ToStatus converts an error returned by a service into a gRPC status error.
+// Domain errors keep their code and message; errors that are not part of the
+// domain are reported as Internal without leaking their details.
+func ToStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	if st, ok := status.FromError(err); ok {
+		return st.Err()
+	}
+	switch {
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case isConnectionError(err):
+		return status.Error(codes.Unavailable, "service unavailable")
+	}
+	return status.Error(codes.Internal, "internal error")
+}
+
+// FromStatus converts a gRPC status error received from another service back
+// into a domain error. Codes without a domain kind are returned unchanged.
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || err == nil {
+		return err
+	}
+	for kind, code := range kindCodes {
+		if st.Code() == code {
+			return &Error{kind: kind, message: st.Message(), status: st}
+		}
+	}
+	return err
+}
+
+// UnaryServerInterceptor converts handler errors with ToStatus
+func UnaryServerInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			log.Println(info.FullMethod, err)
+		}
+		return res, ToStatus(err)
+	}
+	return res, nil
+}
+
+// UnaryClientInterceptor converts errors from the server with FromStatus
+func UnaryClientInterceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	return FromStatus(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// StreamServerInterceptor converts the errors of streaming handlers with ToStatus
+func StreamServerInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if err != nil {
+		if _, ok := status.FromError(err); !ok {
+			log.Println(info.FullMethod, err)
+		}
+		return ToStatus(err)
+	}
+	return nil
+}
+
+// StreamClientInterceptor converts the errors of streaming calls with FromStatus
+func StreamClientInterceptor(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, FromStatus(err)
+	}
+	return &clientStream{ClientStream: stream}, nil
+}
+
+// clientStream converts the errors of the messages sent and received on a stream.
+// io.EOF is not a status, so FromStatus leaves it as is.
+type clientStream struct {
+	grpc.ClientStream
+}
+
+func (s *clientStream) SendMsg(m interface{}) error {
+	return FromStatus(s.ClientStream.SendMsg(m))
+}
+
+func (s *clientStream) RecvMsg(m interface{}) error {
+	return FromStatus(s.ClientStream.RecvMsg(m))
+}
+
+func (s *clientStream) CloseSend() error {
+	return FromStatus(s.ClientStream.CloseSend())
+}
+
+func isConnectionError(err error) bool {
+	var netErr net.Error
+	return errors.As(err, &netErr) ||
+		errors.Is(err, driver.ErrBadConn) ||
+		errors.Is(err, sql.ErrConnDone)
+}
@@ -10,37 +10,35 @@ type accountResolver struct {
 	server *Server
 }
 
-func (r *accountResolver) Orders(ctx context.Context, obj *Account) ([]*Order, error) {
+func (r *accountResolver) Orders(ctx context.Context, obj *Account, first *int, after *string) (*OrderConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	orders, err := r.server.orderClient.GetOrdersForAccount(ctx, obj.ID)
+	size, err := pageSize(first)
+	if err != nil {
+		return nil, err
+	}
+	orders, hasNextPage, err := r.server.orderClient.GetOrdersForAccount(ctx, obj.ID, stringValue(after), size)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
+	return newOrderConnection(orders, hasNextPage), nil
+}
 
-	var result []*Order
-	for _, order := range orders {
-		// Convert OrderProduct to *OrderProduct for GraphQL
-		var orderProducts []*OrderProduct
-		for _, p := range order.Products {
-			orderProducts = append(orderProducts, &OrderProduct{
-				ID:          p.ID,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-				Quantity:    int(p.Quantity),
-			})
-		}
+func (r *accountResolver) Addresses(ctx context.Context, obj *Account) ([]*Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
 
-		result = append(result, &Order{
-			ID:         order.ID,
-			CreatedAt:  order.CreatedAt,
-			TotalPrice: order.TotalPrice,
-			Products:   orderProducts,
-		})
+	addresses, err := r.server.accountClient.GetAddresses(ctx, obj.ID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
 
+	result := []*Address{}
+	for _, a := range addresses {
+		result = append(result, newAddress(&a))
+	}
 	return result, nil
 }
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
+)
+
+type contextKey int
+
+const (
+	callerKey contextKey = iota
+	categoryLoaderKey
+)
+
+// withCaller returns a copy of ctx carrying the account that sent the request
+func withCaller(ctx context.Context, a *account.Account) context.Context {
+	return context.WithValue(ctx, callerKey, a)
+}
+
+// callerFromContext returns the account that sent the request, or nil if the
+// request carried no bearer token
+func callerFromContext(ctx context.Context) *account.Account {
+	a, _ := ctx.Value(callerKey).(*account.Account)
+	return a
+}
+
+// authenticate verifies the bearer token of the request, if there is one, and
+// puts it and the account it was issued to in the request context; the token is
+// forwarded to the services with auth.CallerTokens. Requests without a token
+// pass through anonymously; requests with an invalid token, or a token of a
+// deleted account, are rejected with 401.
+func authenticate(tokens *auth.TokenManager, accounts *account.Client, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header := r.Header.Get("Authorization")
+		if header == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		token, ok := strings.CutPrefix(header, "Bearer ")
+		if !ok {
+			writeAuthError(w, http.StatusUnauthorized, "authorization header must be a bearer token")
+			return
+		}
+		token = strings.TrimSpace(token)
+		identity, err := tokens.VerifyAccessToken(token)
+		if err != nil || identity.Role == auth.RoleService {
+			writeAuthError(w, http.StatusUnauthorized, auth.ErrInvalidToken.Error())
+			return
+		}
+		requestCtx := auth.WithToken(r.Context(), token)
+		ctx, cancel := context.WithTimeout(requestCtx, 3*time.Second)
+		defer cancel()
+		a, err := accounts.GetAccount(ctx, identity.AccountID)
+		if errors.Is(err, errs.ErrNotFound) || (err == nil && a.DeletedAt != nil) {
+			writeAuthError(w, http.StatusUnauthorized, auth.ErrInvalidToken.Error())
+			return
+		}
+		if err != nil {
+			log.Println("Error getting caller account: ", err)
+			writeAuthError(w, http.StatusServiceUnavailable, "account service unavailable")
+			return
+		}
+		next.ServeHTTP(w, r.WithContext(withCaller(requestCtx, a)))
+	})
+}
+
+// hasRole implements the @hasRole directive. The role of the caller is read from
+// its account, so a change of role applies to the gateway before the services,
+// which trust the token until it expires.
+func hasRole(ctx context.Context, obj interface{}, next graphql.Resolver, role Role) (interface{}, error) {
+	caller := callerFromContext(ctx)
+	if caller == nil {
+		return nil, auth.ErrUnauthenticated
+	}
+	if role == RoleAdmin && caller.Role != auth.RoleAdmin {
+		return nil, auth.ErrPermissionDenied
+	}
+	return next(ctx)
+}
+
+// writeAuthError responds with a GraphQL error, coded like the errors of
+// presentError
+func writeAuthError(w http.ResponseWriter, status int, message string) {
+	code := "UNAUTHENTICATED"
+	if status == http.StatusServiceUnavailable {
+		code = "UNAVAILABLE"
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"errors": []map[string]interface{}{{
+			"message":    message,
+			"extensions": map[string]string{"code": code},
+		}},
+	})
+}
+
+func (r *mutationResolver) Register(ctx context.Context, in RegisterInput) (*AuthPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, tokens, err := r.server.accountClient.Register(ctx, in.Name, in.Email, in.Password, stringValue(in.Currency))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &AuthPayload{Account: newAccount(a), Tokens: newAuthTokens(tokens)}, nil
+}
+
+func (r *mutationResolver) Login(ctx context.Context, email string, password string) (*AuthPayload, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, tokens, err := r.server.accountClient.Login(ctx, email, password)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &AuthPayload{Account: newAccount(a), Tokens: newAuthTokens(tokens)}, nil
+}
+
+func (r *mutationResolver) RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	tokens, err := r.server.accountClient.RefreshToken(ctx, refreshToken)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newAuthTokens(tokens), nil
+}
+
+func (r *queryResolver) Me(ctx context.Context) (*Account, error) {
+	caller := callerFromContext(ctx)
+	if caller == nil {
+		return nil, nil
+	}
+	return newAccount(caller), nil
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+	"google.golang.org/grpc/status"
+)
+
+// presentError adds the gRPC status code of service errors to the GraphQL error
+// as extensions.code (e.g. NOT_FOUND, INVALID_ARGUMENT), so clients can react
+// to the kind of failure without parsing messages
+func presentError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+	st, ok := status.FromError(err)
+	if !ok {
+		return gqlErr
+	}
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]interface{}{}
+	}
+	gqlErr.Extensions["code"] = upperSnake(st.Code().String())
+	return gqlErr
+}
+
+// upperSnake turns a code name like "FailedPrecondition" into "FAILED_PRECONDITION"
+func upperSnake(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
@@ -4,6 +4,8 @@ package main
 import (
 	"github.com/99designs/gqlgen/graphql"
 	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/cart"
 	"github.com/sdshah09/GoCore/order"
 	"github.com/sdshah09/GoCore/product"
 )
@@ -12,32 +14,42 @@ type Server struct {
 	accountClient *account.Client
 	productClient *product.Client
 	orderClient   *order.Client
+	cartClient    *cart.Client
 }
 
 // *Server pointer means we return reference because it is cheap rather than cerating instance and then returning it
-func NewGraphQLServer(accountUrl, productUrl, orderUrl string) (*Server, error) {
-	accountClient, err := account.NewClient(accountUrl)
+func NewGraphQLServer(accountUrl, productUrl, orderUrl, cartUrl string) (*Server, error) {
+	accountClient, err := account.NewClient(accountUrl, auth.CallerTokens)
 	if err != nil {
 		return nil, err
 	}
 
-	productClient, err := product.NewClient(productUrl)
+	productClient, err := product.NewClient(productUrl, auth.CallerTokens)
 	if err != nil {
 		accountClient.Close()
 		return nil, err
 	}
 
-	orderClient, err := order.NewClient(orderUrl)
+	orderClient, err := order.NewClient(orderUrl, auth.CallerTokens)
 	if err != nil {
 		accountClient.Close()
 		productClient.Close()
 		return nil, err
 	}
 
+	cartClient, err := cart.NewClient(cartUrl, auth.CallerTokens)
+	if err != nil {
+		accountClient.Close()
+		productClient.Close()
+		orderClient.Close()
+		return nil, err
+	}
+
 	return &Server{
 		accountClient: accountClient,
 		productClient: productClient,
 		orderClient:   orderClient,
+		cartClient:    cartClient,
 	}, nil
 }
 
@@ -59,8 +71,17 @@ func (s *Server) Account() AccountResolver {
 	}
 }
 
+func (s *Server) Product() ProductResolver {
+	return &productResolver{
+		server: s,
+	}
+}
+
 func (s *Server) ToExecutableSchema() graphql.ExecutableSchema {
 	return NewExecutableSchema(Config{
 		Resolvers: s,
+		Directives: DirectiveRoot{
+			HasRole: hasRole,
+		},
 	})
 }
@@ -7,12 +7,16 @@ import (
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/auth"
 )
 
 type AppConfig struct {
 	AccountURL string `envconfig:"ACCOUNT_SERVICE_URL"`
 	ProductURL string `envconfig:"PRODUCT_SERVICE_URL"`
 	OrderURL   string `envconfig:"ORDER_SERVICE_URL"`
+	CartURL    string `envconfig:"CART_SERVICE_URL"`
+	// JWTSecret verifies the access tokens issued by the account service
+	JWTSecret string `envconfig:"JWT_SECRET" required:"true"`
 }
 
 func main() {
@@ -22,11 +26,20 @@ func main() {
 		log.Fatal(err)
 	}
 
-	server, err := NewGraphQLServer(cfg.AccountURL, cfg.ProductURL, cfg.OrderURL)
+	// Only access tokens are verified here, so the TTLs are not used
+	tokens, err := auth.NewTokenManager([]byte(cfg.JWTSecret), 0, 0)
 	if err != nil {
 		log.Fatal(err)
 	}
-	http.Handle("/graphql", handler.NewDefaultServer(server.ToExecutableSchema()))
+
+	server, err := NewGraphQLServer(cfg.AccountURL, cfg.ProductURL, cfg.OrderURL, cfg.CartURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	srv := handler.NewDefaultServer(server.ToExecutableSchema())
+	srv.SetErrorPresenter(presentError)
+	srv.AroundOperations(withCategoryLoader)
+	http.Handle("/graphql", authenticate(tokens, server.accountClient, srv))
 	http.Handle("/playground", playground.Handler("shaswat", "/graphql"))
 	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
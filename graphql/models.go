@@ -1,9 +1,289 @@
 package main
 
-// import "time"
+import (
+	"strings"
+	"time"
+
+	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/cart"
+	"github.com/sdshah09/GoCore/money"
+	"github.com/sdshah09/GoCore/order"
+	"github.com/sdshah09/GoCore/page"
+	"github.com/sdshah09/GoCore/product"
+)
 
 type Account struct {
-	ID     string  `json:"id"` // these are the json serialization mapping of ID --> id
-	Name   string  `json:"name"`
-	Orders []Order `json:"orders"`
+	ID        string     `json:"id"` // these are the json serialization mapping of ID --> id
+	Name      string     `json:"name"`
+	DeletedAt *time.Time `json:"deletedAt"`
+	Currency  string     `json:"currency"`
+	Email     *string    `json:"email"`
+	Role      Role       `json:"role"`
+}
+
+// newAccount converts an account returned by the account service into its GraphQL model
+func newAccount(a *account.Account) *Account {
+	result := &Account{
+		ID:        a.ID,
+		Name:      a.Name,
+		DeletedAt: a.DeletedAt,
+		Currency:  a.Currency,
+		Role:      Role(strings.ToUpper(string(a.Role))),
+	}
+	if a.Email != "" {
+		result.Email = &a.Email
+	}
+	return result
+}
+
+// newAccountConnection converts a page of accounts into a connection, with each
+// account's cursor made from its ID
+func newAccountConnection(accounts []account.Account, hasNextPage bool) *AccountConnection {
+	result := &AccountConnection{Edges: []*AccountEdge{}, PageInfo: &PageInfo{HasNextPage: hasNextPage}}
+	for i := range accounts {
+		cursor := page.Cursor(accounts[i].ID)
+		result.Edges = append(result.Edges, &AccountEdge{Cursor: cursor, Node: newAccount(&accounts[i])})
+		result.PageInfo.EndCursor = &cursor
+	}
+	return result
+}
+
+// newAuthTokens converts tokens issued by the account service into their GraphQL model
+func newAuthTokens(t *auth.Tokens) *AuthTokens {
+	return &AuthTokens{
+		AccessToken:  t.AccessToken,
+		RefreshToken: t.RefreshToken,
+		ExpiresAt:    t.ExpiresAt,
+	}
+}
+
+// newAddress converts an address returned by the account service into its GraphQL model
+func newAddress(a *account.Address) *Address {
+	return &Address{
+		ID:              a.ID,
+		Name:            a.Name,
+		Line1:           a.Line1,
+		Line2:           a.Line2,
+		City:            a.City,
+		Subdivision:     a.Subdivision,
+		PostalCode:      a.PostalCode,
+		Country:         a.Country,
+		DefaultShipping: a.DefaultShipping,
+		DefaultBilling:  a.DefaultBilling,
+	}
+}
+
+// address converts the input into an account address without an ID or account
+func (in AddressInput) address() account.Address {
+	return account.Address{
+		Name:            in.Name,
+		Line1:           in.Line1,
+		Line2:           stringValue(in.Line2),
+		City:            in.City,
+		Subdivision:     stringValue(in.Subdivision),
+		PostalCode:      stringValue(in.PostalCode),
+		Country:         in.Country,
+		DefaultShipping: in.DefaultShipping != nil && *in.DefaultShipping,
+		DefaultBilling:  in.DefaultBilling != nil && *in.DefaultBilling,
+	}
+}
+
+type Product struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Stock       int         `json:"stock"`
+	WeightGrams int         `json:"weightGrams"`
+	// CategorySlugs are resolved into categories by productResolver.Categories
+	CategorySlugs []string `json:"-"`
+}
+
+// newProduct converts a product returned by the product service into its GraphQL model
+func newProduct(p *product.Product) *Product {
+	return &Product{
+		ID:            p.ID,
+		Name:          p.Name,
+		Description:   p.Description,
+		Price:         p.Price,
+		Stock:         int(p.Stock),
+		WeightGrams:   int(p.WeightGrams),
+		CategorySlugs: p.Categories,
+	}
+}
+
+type Category struct {
+	Slug     string      `json:"slug"`
+	Name     string      `json:"name"`
+	Parent   *Category   `json:"parent"`
+	Children []*Category `json:"children"`
+}
+
+// newCategoryTree links the categories returned by the product service into a
+// tree. It returns the top-level categories, and every category by slug.
+func newCategoryTree(categories []product.Category) ([]*Category, map[string]*Category) {
+	bySlug := map[string]*Category{}
+	for _, c := range categories {
+		bySlug[c.Slug] = &Category{Slug: c.Slug, Name: c.Name, Children: []*Category{}}
+	}
+	roots := []*Category{}
+	for _, c := range categories {
+		category := bySlug[c.Slug]
+		if parent, ok := bySlug[c.Parent]; ok {
+			category.Parent = parent
+			parent.Children = append(parent.Children, category)
+		} else {
+			roots = append(roots, category)
+		}
+	}
+	return roots, bySlug
+}
+
+// newProductSearchResult converts a search result returned by the product
+// service into its GraphQL model
+func newProductSearchResult(r *product.SearchResult) *ProductSearchResult {
+	result := &ProductSearchResult{
+		Products: []*Product{},
+		Total:    int(r.Total),
+		Facets:   &ProductFacets{PriceRanges: []*PriceRangeFacet{}, Categories: []*CategoryFacet{}},
+	}
+	for i := range r.Products {
+		result.Products = append(result.Products, newProduct(&r.Products[i]))
+	}
+	for _, f := range r.Facets.PriceRanges {
+		result.Facets.PriceRanges = append(result.Facets.PriceRanges, &PriceRangeFacet{From: f.From, To: f.To, Count: int(f.Count)})
+	}
+	for _, f := range r.Facets.Categories {
+		result.Facets.Categories = append(result.Facets.Categories, &CategoryFacet{Category: f.Category, Count: int(f.Count)})
+	}
+	return result
+}
+
+// newOrder converts an order returned by the order service into its GraphQL model
+func newOrder(o *order.Order) *Order {
+	result := &Order{
+		ID:            o.ID,
+		CreatedAt:     o.CreatedAt,
+		Subtotal:      o.Subtotal,
+		Tax:           o.Tax,
+		Shipping:      o.Shipping,
+		TotalPrice:    o.TotalPrice,
+		Region:        o.Region,
+		Status:        OrderStatus(strings.ToUpper(string(o.Status))),
+		StatusHistory: []*OrderStatusChange{},
+		Products:      []*OrderProduct{},
+		Discounts:     []*OrderDiscount{},
+	}
+	if o.CouponCode != "" {
+		result.CouponCode = &o.CouponCode
+	}
+	if a := o.ShippingAddress; a != nil {
+		result.ShippingAddress = &OrderAddress{
+			AddressID:   a.AddressID,
+			Name:        a.Name,
+			Line1:       a.Line1,
+			Line2:       a.Line2,
+			City:        a.City,
+			Subdivision: a.Subdivision,
+			PostalCode:  a.PostalCode,
+			Country:     a.Country,
+		}
+	}
+	for _, change := range o.StatusHistory {
+		result.StatusHistory = append(result.StatusHistory, &OrderStatusChange{
+			Status:    OrderStatus(strings.ToUpper(string(change.Status))),
+			ChangedAt: change.ChangedAt,
+			Actor:     change.Actor,
+		})
+	}
+	for _, p := range o.Products {
+		result.Products = append(result.Products, &OrderProduct{
+			ID:           p.ID,
+			Name:         p.Name,
+			Description:  p.Description,
+			Price:        p.Price,
+			CatalogPrice: p.CatalogPrice,
+			ExchangeRate: p.ExchangeRate,
+			Quantity:     int(p.Quantity),
+			WeightGrams:  int(p.WeightGrams),
+		})
+	}
+	for _, d := range o.Discounts {
+		discount := &OrderDiscount{Description: d.Description, Amount: d.Amount}
+		if d.ProductID != "" {
+			productID := d.ProductID
+			discount.ProductID = &productID
+		}
+		result.Discounts = append(result.Discounts, discount)
+	}
+	return result
+}
+
+// newOrderConnection converts a page of orders into a connection, with each
+// order's cursor made from its ID
+func newOrderConnection(orders []order.Order, hasNextPage bool) *OrderConnection {
+	result := &OrderConnection{Edges: []*OrderEdge{}, PageInfo: &PageInfo{HasNextPage: hasNextPage}}
+	for i := range orders {
+		cursor := page.Cursor(orders[i].ID)
+		result.Edges = append(result.Edges, &OrderEdge{Cursor: cursor, Node: newOrder(&orders[i])})
+		result.PageInfo.EndCursor = &cursor
+	}
+	return result
+}
+
+// newCoupon converts a coupon returned by the order service into its GraphQL
+// model, leaving out the fields its discount type does not use
+func newCoupon(c *order.Coupon) *Coupon {
+	result := &Coupon{
+		Code:      c.Code,
+		Type:      DiscountType(strings.ToUpper(string(c.Type))),
+		MaxUses:   int(c.MaxUses),
+		Uses:      int(c.Uses),
+		ExpiresAt: c.ExpiresAt,
+	}
+	switch c.Type {
+	case order.DiscountPercent:
+		percentOff := int(c.PercentOff)
+		result.PercentOff = &percentOff
+	case order.DiscountFixed:
+		amountOff := c.AmountOff
+		result.AmountOff = &amountOff
+	case order.DiscountBuyXGetY:
+		buyQuantity, getQuantity := int(c.BuyQuantity), int(c.GetQuantity)
+		result.BuyQuantity = &buyQuantity
+		result.GetQuantity = &getQuantity
+	}
+	if c.ProductID != "" {
+		result.ProductID = &c.ProductID
+	}
+	return result
+}
+
+// newCart converts a cart returned by the cart service into its GraphQL model
+func newCart(c *cart.Cart) (*Cart, error) {
+	totals, err := c.Totals()
+	if err != nil {
+		return nil, err
+	}
+	result := &Cart{
+		AccountID: c.AccountID,
+		Items:     []*CartItem{},
+		Totals:    []*money.Money{},
+	}
+	for i := range totals {
+		result.Totals = append(result.Totals, &totals[i])
+	}
+	for _, item := range c.Items {
+		result.Items = append(result.Items, &CartItem{
+			ProductID:    item.ProductID,
+			Name:         item.Name,
+			Quantity:     int(item.Quantity),
+			Price:        item.Price,
+			PriceChanged: item.PriceChanged,
+			Available:    item.Available,
+			AddedAt:      item.AddedAt,
+		})
+	}
+	return result, nil
 }
@@ -3,34 +3,208 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
 	"time"
+
+	"github.com/sdshah09/GoCore/money"
 )
 
+type AccountConnection struct {
+	Edges    []*AccountEdge `json:"edges"`
+	PageInfo *PageInfo      `json:"pageInfo"`
+}
+
+type AccountEdge struct {
+	Cursor string   `json:"cursor"`
+	Node   *Account `json:"node"`
+}
+
 type AccountInput struct {
-	Name string `json:"name"`
+	Name           string  `json:"name"`
+	Email          *string `json:"email,omitempty"`
+	Currency       *string `json:"currency,omitempty"`
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+}
+
+type AccountUpdateInput struct {
+	Name     string  `json:"name"`
+	Currency *string `json:"currency,omitempty"`
+}
+
+type Address struct {
+	ID              string `json:"id"`
+	Name            string `json:"name"`
+	Line1           string `json:"line1"`
+	Line2           string `json:"line2"`
+	City            string `json:"city"`
+	Subdivision     string `json:"subdivision"`
+	PostalCode      string `json:"postalCode"`
+	Country         string `json:"country"`
+	DefaultShipping bool   `json:"defaultShipping"`
+	DefaultBilling  bool   `json:"defaultBilling"`
+}
+
+type AddressInput struct {
+	Name            string  `json:"name"`
+	Line1           string  `json:"line1"`
+	Line2           *string `json:"line2,omitempty"`
+	City            string  `json:"city"`
+	Subdivision     *string `json:"subdivision,omitempty"`
+	PostalCode      *string `json:"postalCode,omitempty"`
+	Country         string  `json:"country"`
+	DefaultShipping *bool   `json:"defaultShipping,omitempty"`
+	DefaultBilling  *bool   `json:"defaultBilling,omitempty"`
+}
+
+type AuthPayload struct {
+	Account *Account    `json:"account"`
+	Tokens  *AuthTokens `json:"tokens"`
+}
+
+type AuthTokens struct {
+	AccessToken  string    `json:"accessToken"`
+	RefreshToken string    `json:"refreshToken"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
+
+type Cart struct {
+	AccountID string         `json:"accountId"`
+	Items     []*CartItem    `json:"items"`
+	Totals    []*money.Money `json:"totals"`
+}
+
+type CartItem struct {
+	ProductID    string      `json:"productId"`
+	Name         string      `json:"name"`
+	Quantity     int         `json:"quantity"`
+	Price        money.Money `json:"price"`
+	PriceChanged bool        `json:"priceChanged"`
+	Available    bool        `json:"available"`
+	AddedAt      time.Time   `json:"addedAt"`
+}
+
+type CartItemInput struct {
+	AccountID string `json:"accountId"`
+	ProductID string `json:"productId"`
+	Quantity  int    `json:"quantity"`
+}
+
+type CategoryFacet struct {
+	Category string `json:"category"`
+	Count    int    `json:"count"`
+}
+
+type CategoryInput struct {
+	Slug   string  `json:"slug"`
+	Name   string  `json:"name"`
+	Parent *string `json:"parent,omitempty"`
+}
+
+type CategoryUpdateInput struct {
+	Name   string  `json:"name"`
+	Parent *string `json:"parent,omitempty"`
+}
+
+type CheckoutInput struct {
+	AccountID      string  `json:"accountId"`
+	Region         *string `json:"region,omitempty"`
+	AddressID      *string `json:"addressId,omitempty"`
+	CouponCode     *string `json:"couponCode,omitempty"`
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+}
+
+type Coupon struct {
+	Code        string       `json:"code"`
+	Type        DiscountType `json:"type"`
+	PercentOff  *int         `json:"percentOff,omitempty"`
+	AmountOff   *money.Money `json:"amountOff,omitempty"`
+	BuyQuantity *int         `json:"buyQuantity,omitempty"`
+	GetQuantity *int         `json:"getQuantity,omitempty"`
+	ProductID   *string      `json:"productId,omitempty"`
+	MaxUses     int          `json:"maxUses"`
+	Uses        int          `json:"uses"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
+}
+
+type CouponInput struct {
+	Code        string       `json:"code"`
+	Type        DiscountType `json:"type"`
+	PercentOff  *int         `json:"percentOff,omitempty"`
+	AmountOff   *money.Money `json:"amountOff,omitempty"`
+	BuyQuantity *int         `json:"buyQuantity,omitempty"`
+	GetQuantity *int         `json:"getQuantity,omitempty"`
+	ProductID   *string      `json:"productId,omitempty"`
+	MaxUses     *int         `json:"maxUses,omitempty"`
+	ExpiresAt   *time.Time   `json:"expiresAt,omitempty"`
 }
 
 type Mutation struct {
 }
 
 type Order struct {
-	ID         string          `json:"id"`
-	CreatedAt  time.Time       `json:"createdAt"`
-	TotalPrice float64         `json:"totalPrice"`
-	Products   []*OrderProduct `json:"products"`
+	ID              string               `json:"id"`
+	CreatedAt       time.Time            `json:"createdAt"`
+	Subtotal        money.Money          `json:"subtotal"`
+	Tax             money.Money          `json:"tax"`
+	Shipping        money.Money          `json:"shipping"`
+	TotalPrice      money.Money          `json:"totalPrice"`
+	Region          string               `json:"region"`
+	ShippingAddress *OrderAddress        `json:"shippingAddress,omitempty"`
+	Status          OrderStatus          `json:"status"`
+	StatusHistory   []*OrderStatusChange `json:"statusHistory"`
+	Products        []*OrderProduct      `json:"products"`
+	CouponCode      *string              `json:"couponCode,omitempty"`
+	Discounts       []*OrderDiscount     `json:"discounts"`
+}
+
+type OrderAddress struct {
+	AddressID   string `json:"addressId"`
+	Name        string `json:"name"`
+	Line1       string `json:"line1"`
+	Line2       string `json:"line2"`
+	City        string `json:"city"`
+	Subdivision string `json:"subdivision"`
+	PostalCode  string `json:"postalCode"`
+	Country     string `json:"country"`
+}
+
+type OrderConnection struct {
+	Edges    []*OrderEdge `json:"edges"`
+	PageInfo *PageInfo    `json:"pageInfo"`
+}
+
+type OrderDiscount struct {
+	ProductID   *string     `json:"productId,omitempty"`
+	Description string      `json:"description"`
+	Amount      money.Money `json:"amount"`
+}
+
+type OrderEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Order `json:"node"`
 }
 
 type OrderInput struct {
-	AccountID string               `json:"accountId"`
-	Products  []*OrderProductInput `json:"products"`
+	AccountID      string               `json:"accountId"`
+	Products       []*OrderProductInput `json:"products"`
+	CouponCode     *string              `json:"couponCode,omitempty"`
+	Region         *string              `json:"region,omitempty"`
+	AddressID      *string              `json:"addressId,omitempty"`
+	IdempotencyKey *string              `json:"idempotencyKey,omitempty"`
 }
 
 type OrderProduct struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
-	Quantity    int     `json:"quantity"`
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Description  string      `json:"description"`
+	Price        money.Money `json:"price"`
+	CatalogPrice money.Money `json:"catalogPrice"`
+	ExchangeRate string      `json:"exchangeRate"`
+	Quantity     int         `json:"quantity"`
+	WeightGrams  int         `json:"weightGrams"`
 }
 
 type OrderProductInput struct {
@@ -38,23 +212,309 @@ type OrderProductInput struct {
 	Quantity int    `json:"quantity"`
 }
 
+type OrderStatusChange struct {
+	Status    OrderStatus `json:"status"`
+	ChangedAt time.Time   `json:"changedAt"`
+	Actor     string      `json:"actor"`
+}
+
+type PageInfo struct {
+	HasNextPage bool    `json:"hasNextPage"`
+	EndCursor   *string `json:"endCursor,omitempty"`
+}
+
 type PaginationInput struct {
 	Skip *int `json:"skip,omitempty"`
 	Take *int `json:"take,omitempty"`
 }
 
-type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+type PriceRangeFacet struct {
+	From  *money.Money `json:"from,omitempty"`
+	To    *money.Money `json:"to,omitempty"`
+	Count int          `json:"count"`
+}
+
+type ProductFacets struct {
+	PriceRanges []*PriceRangeFacet `json:"priceRanges"`
+	Categories  []*CategoryFacet   `json:"categories"`
+}
+
+type ProductFilter struct {
+	MinPrice   *money.Money `json:"minPrice,omitempty"`
+	MaxPrice   *money.Money `json:"maxPrice,omitempty"`
+	Categories []string     `json:"categories,omitempty"`
 }
 
 type ProductInput struct {
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	Name           string      `json:"name"`
+	Description    string      `json:"description"`
+	Price          money.Money `json:"price"`
+	Stock          *int        `json:"stock,omitempty"`
+	WeightGrams    *int        `json:"weightGrams,omitempty"`
+	Categories     []string    `json:"categories,omitempty"`
+	IdempotencyKey *string     `json:"idempotencyKey,omitempty"`
+}
+
+type ProductSearchResult struct {
+	Products []*Product     `json:"products"`
+	Total    int            `json:"total"`
+	Facets   *ProductFacets `json:"facets"`
+}
+
+type ProductSuggestion struct {
+	ProductID string `json:"productId"`
+	Name      string `json:"name"`
+	Highlight string `json:"highlight"`
+}
+
+type ProductUpdateInput struct {
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Price       *money.Money `json:"price,omitempty"`
+	WeightGrams *int         `json:"weightGrams,omitempty"`
+	Categories  []string     `json:"categories,omitempty"`
 }
 
 type Query struct {
 }
+
+type RegisterInput struct {
+	Name     string  `json:"name"`
+	Email    string  `json:"email"`
+	Password string  `json:"password"`
+	Currency *string `json:"currency,omitempty"`
+}
+
+type DiscountType string
+
+const (
+	DiscountTypePercent  DiscountType = "PERCENT"
+	DiscountTypeFixed    DiscountType = "FIXED"
+	DiscountTypeBuyXGetY DiscountType = "BUY_X_GET_Y"
+)
+
+var AllDiscountType = []DiscountType{
+	DiscountTypePercent,
+	DiscountTypeFixed,
+	DiscountTypeBuyXGetY,
+}
+
+func (e DiscountType) IsValid() bool {
+	switch e {
+	case DiscountTypePercent, DiscountTypeFixed, DiscountTypeBuyXGetY:
+		return true
+	}
+	return false
+}
+
+func (e DiscountType) String() string {
+	return string(e)
+}
+
+func (e *DiscountType) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = DiscountType(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid DiscountType", str)
+	}
+	return nil
+}
+
+func (e DiscountType) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *DiscountType) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e DiscountType) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type OrderStatus string
+
+const (
+	OrderStatusPending   OrderStatus = "PENDING"
+	OrderStatusPaid      OrderStatus = "PAID"
+	OrderStatusShipped   OrderStatus = "SHIPPED"
+	OrderStatusDelivered OrderStatus = "DELIVERED"
+	OrderStatusCancelled OrderStatus = "CANCELLED"
+	OrderStatusRefunded  OrderStatus = "REFUNDED"
+)
+
+var AllOrderStatus = []OrderStatus{
+	OrderStatusPending,
+	OrderStatusPaid,
+	OrderStatusShipped,
+	OrderStatusDelivered,
+	OrderStatusCancelled,
+	OrderStatusRefunded,
+}
+
+func (e OrderStatus) IsValid() bool {
+	switch e {
+	case OrderStatusPending, OrderStatusPaid, OrderStatusShipped, OrderStatusDelivered, OrderStatusCancelled, OrderStatusRefunded:
+		return true
+	}
+	return false
+}
+
+func (e OrderStatus) String() string {
+	return string(e)
+}
+
+func (e *OrderStatus) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderStatus(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderStatus", str)
+	}
+	return nil
+}
+
+func (e OrderStatus) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderStatus) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderStatus) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type ProductSort string
+
+const (
+	ProductSortRelevance ProductSort = "RELEVANCE"
+	ProductSortPriceAsc  ProductSort = "PRICE_ASC"
+	ProductSortPriceDesc ProductSort = "PRICE_DESC"
+	ProductSortNewest    ProductSort = "NEWEST"
+)
+
+var AllProductSort = []ProductSort{
+	ProductSortRelevance,
+	ProductSortPriceAsc,
+	ProductSortPriceDesc,
+	ProductSortNewest,
+}
+
+func (e ProductSort) IsValid() bool {
+	switch e {
+	case ProductSortRelevance, ProductSortPriceAsc, ProductSortPriceDesc, ProductSortNewest:
+		return true
+	}
+	return false
+}
+
+func (e ProductSort) String() string {
+	return string(e)
+}
+
+func (e *ProductSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductSort", str)
+	}
+	return nil
+}
+
+func (e ProductSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProductSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProductSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type Role string
+
+const (
+	RoleCustomer Role = "CUSTOMER"
+	RoleAdmin    Role = "ADMIN"
+)
+
+var AllRole = []Role{
+	RoleCustomer,
+	RoleAdmin,
+}
+
+func (e Role) IsValid() bool {
+	switch e {
+	case RoleCustomer, RoleAdmin:
+		return true
+	}
+	return false
+}
+
+func (e Role) String() string {
+	return string(e)
+}
+
+func (e *Role) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = Role(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid Role", str)
+	}
+	return nil
+}
+
+func (e Role) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *Role) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e Role) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/sdshah09/GoCore/money"
+)
+
+// MarshalMoney writes an amount as a decimal string followed by its currency
+// code, e.g. "19.99 USD", so no precision is lost in JSON numbers
+func MarshalMoney(m money.Money) graphql.Marshaler {
+	return graphql.WriterFunc(func(w io.Writer) {
+		io.WriteString(w, strconv.Quote(m.String()))
+	})
+}
+
+// UnmarshalMoney reads an amount such as "19.99 USD". The currency may be
+// omitted, and plain numbers are accepted for clients that still send floats;
+// both are taken to be in the default currency.
+func UnmarshalMoney(v interface{}) (money.Money, error) {
+	var amount string
+	switch v := v.(type) {
+	case string:
+		amount = v
+	case json.Number:
+		amount = v.String()
+	case int64:
+		amount = strconv.FormatInt(v, 10)
+	case int:
+		amount = strconv.Itoa(v)
+	case float64:
+		amount = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return money.Money{}, fmt.Errorf("%T is not a valid Money", v)
+	}
+	decimal, currency, found := strings.Cut(strings.TrimSpace(amount), " ")
+	if !found {
+		currency = money.DefaultCurrency
+	}
+	return money.Parse(decimal, currency)
+}
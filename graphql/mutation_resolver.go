@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
-	"errors"
 	"log"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/sdshah09/GoCore/errs"
 	"github.com/sdshah09/GoCore/order"
+	"github.com/sdshah09/GoCore/product"
 )
 
 // import "context"
@@ -16,51 +19,159 @@ type mutationResolver struct {
 }
 
 var (
-	ErrInvalidParameter = errors.New("invalid parameter")
+	ErrInvalidParameter = errs.InvalidArgument("invalid parameter")
 )
 
 func (r *mutationResolver) CreateAccount(ctx context.Context, in AccountInput) (*Account, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	a, err := r.server.accountClient.PostAccount(ctx, in.Name)
+	a, err := r.server.accountClient.PostAccount(ctx, in.Name, stringValue(in.Email), stringValue(in.Currency), stringValue(in.IdempotencyKey))
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	return &Account{
-		ID:   a.ID,
-		Name: a.Name,
-	}, nil
+	return newAccount(a), nil
+}
+
+func (r *mutationResolver) UpdateAccount(ctx context.Context, id string, in AccountUpdateInput) (*Account, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	a, err := r.server.accountClient.UpdateAccount(ctx, id, in.Name, stringValue(in.Currency))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newAccount(a), nil
+}
+
+func (r *mutationResolver) DeleteAccount(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.accountClient.DeleteAccount(ctx, id); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) CreateAddress(ctx context.Context, accountID string, in AddressInput) (*Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	address := in.address()
+	address.AccountID = accountID
+	a, err := r.server.accountClient.PostAddress(ctx, address)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newAddress(a), nil
+}
+
+func (r *mutationResolver) UpdateAddress(ctx context.Context, id string, in AddressInput) (*Address, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	address := in.address()
+	address.ID = id
+	a, err := r.server.accountClient.UpdateAddress(ctx, address)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newAddress(a), nil
+}
+
+func (r *mutationResolver) DeleteAddress(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.accountClient.DeleteAddress(ctx, id); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
 }
 
 func (r *mutationResolver) CreateProduct(ctx context.Context, in ProductInput) (*Product, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	p, err := r.server.productClient.PostProduct(ctx, in.Name, in.Description, in.Price)
+	stock := uint32(0)
+	if in.Stock != nil {
+		if !fitsUint32(*in.Stock) {
+			return nil, ErrInvalidParameter
+		}
+		stock = uint32(*in.Stock)
+	}
+	weightGrams := uint32(0)
+	if in.WeightGrams != nil {
+		if !fitsUint32(*in.WeightGrams) {
+			return nil, ErrInvalidParameter
+		}
+		weightGrams = uint32(*in.WeightGrams)
+	}
+	p, err := r.server.productClient.PostProduct(ctx, in.Name, in.Description, in.Price, stock, weightGrams, in.Categories, stringValue(in.IdempotencyKey))
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	return &Product{
-		ID:          p.ID,
-		Name:        p.Name,
-		Description: p.Description,
-		Price:       p.Price,
-	}, nil
+	return newProduct(p), nil
 
 }
 
+func (r *mutationResolver) UpdateProduct(ctx context.Context, id string, in ProductUpdateInput) (*Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	var weightGrams *uint32
+	if in.WeightGrams != nil {
+		if !fitsUint32(*in.WeightGrams) {
+			return nil, ErrInvalidParameter
+		}
+		w := uint32(*in.WeightGrams)
+		weightGrams = &w
+	}
+	update := product.ProductUpdate{
+		Name:        in.Name,
+		Description: in.Description,
+		Price:       in.Price,
+		WeightGrams: weightGrams,
+	}
+	if in.Categories != nil {
+		update.Categories = &in.Categories
+	}
+	p, err := r.server.productClient.UpdateProduct(ctx, id, update)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.productClient.DeleteProduct(ctx, id); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Order, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	var products []order.OrderedProduct
 	for _, p := range in.Products {
-		if p.Quantity <= 0 {
+		if !validQuantity(p.Quantity) {
 			return nil, ErrInvalidParameter
 		}
 		products = append(products, order.OrderedProduct{
@@ -68,27 +179,231 @@ func (r *mutationResolver) CreateOrder(ctx context.Context, in OrderInput) (*Ord
 			Quantity: uint32(p.Quantity),
 		})
 	}
-	o, err := r.server.orderClient.PostOrder(ctx, in.AccountID, products)
+	o, err := r.server.orderClient.PostOrder(ctx, in.AccountID, stringValue(in.Region), stringValue(in.AddressID), products, stringValue(in.CouponCode), stringValue(in.IdempotencyKey))
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
 
-	var orderProducts []*OrderProduct
-	for _, p := range o.Products {
-		orderProducts = append(orderProducts, &OrderProduct{
-			ID:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			Quantity:    int(p.Quantity),
-		})
+	return newOrder(o), nil
+}
+
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) (*Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	o, err := r.server.orderClient.UpdateOrderStatus(ctx, id, order.OrderStatus(strings.ToLower(string(status))))
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
+	return newOrder(o), nil
+}
+
+func (r *mutationResolver) CancelOrder(ctx context.Context, id string) (*Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	o, err := r.server.orderClient.CancelOrder(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newOrder(o), nil
+}
+
+func (r *mutationResolver) SetProductStock(ctx context.Context, id string, stock int) (*Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if !fitsUint32(stock) {
+		return nil, ErrInvalidParameter
+	}
+	p, err := r.server.productClient.SetStock(ctx, id, uint32(stock))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) AdjustProductStock(ctx context.Context, id string, delta int) (*Product, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if delta < math.MinInt32 || delta > math.MaxInt32 {
+		return nil, ErrInvalidParameter
+	}
+	p, err := r.server.productClient.AdjustStock(ctx, id, int32(delta))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newProduct(p), nil
+}
+
+func (r *mutationResolver) CreateCoupon(ctx context.Context, in CouponInput) (*Coupon, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	coupon := order.Coupon{
+		Code:      in.Code,
+		Type:      order.DiscountType(strings.ToLower(string(in.Type))),
+		ProductID: stringValue(in.ProductID),
+		ExpiresAt: in.ExpiresAt,
+	}
+	if in.AmountOff != nil {
+		coupon.AmountOff = *in.AmountOff
+	}
+	for _, field := range []struct {
+		value  *int
+		target *uint32
+	}{
+		{in.PercentOff, &coupon.PercentOff},
+		{in.BuyQuantity, &coupon.BuyQuantity},
+		{in.GetQuantity, &coupon.GetQuantity},
+		{in.MaxUses, &coupon.MaxUses},
+	} {
+		if field.value == nil {
+			continue
+		}
+		if !fitsUint32(*field.value) {
+			return nil, ErrInvalidParameter
+		}
+		*field.target = uint32(*field.value)
+	}
+	c, err := r.server.orderClient.PostCoupon(ctx, coupon)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newCoupon(c), nil
+}
+
+func (r *mutationResolver) CreateCategory(ctx context.Context, in CategoryInput) (*Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.productClient.PostCategory(ctx, in.Slug, in.Name, stringValue(in.Parent))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.server.newCategory(ctx, c)
+}
+
+func (r *mutationResolver) UpdateCategory(ctx context.Context, slug string, in CategoryUpdateInput) (*Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.productClient.UpdateCategory(ctx, slug, in.Name, stringValue(in.Parent))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return r.server.newCategory(ctx, c)
+}
+
+func (r *mutationResolver) DeleteCategory(ctx context.Context, slug string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.productClient.DeleteCategory(ctx, slug); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *mutationResolver) AddToCart(ctx context.Context, in CartItemInput) (*Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if !validQuantity(in.Quantity) {
+		return nil, ErrInvalidParameter
+	}
+	c, err := r.server.cartClient.AddItem(ctx, in.AccountID, in.ProductID, uint32(in.Quantity))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newCart(c)
+}
+
+func (r *mutationResolver) UpdateCartItem(ctx context.Context, in CartItemInput) (*Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	// A quantity of 0 removes the item
+	if in.Quantity != 0 && !validQuantity(in.Quantity) {
+		return nil, ErrInvalidParameter
+	}
+	c, err := r.server.cartClient.UpdateItemQuantity(ctx, in.AccountID, in.ProductID, uint32(in.Quantity))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newCart(c)
+}
+
+func (r *mutationResolver) RemoveFromCart(ctx context.Context, accountID string, productID string) (*Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.cartClient.RemoveItem(ctx, accountID, productID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newCart(c)
+}
+
+func (r *mutationResolver) ClearCart(ctx context.Context, accountID string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	if err := r.server.cartClient.ClearCart(ctx, accountID); err != nil {
+		log.Println(err)
+		return false, err
+	}
+	return true, nil
+}
+
+// Checkout places the order through the cart service, then reads it back from
+// the order service
+func (r *mutationResolver) Checkout(ctx context.Context, in CheckoutInput) (*Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	orderID, err := r.server.cartClient.Checkout(ctx, in.AccountID, stringValue(in.Region), stringValue(in.AddressID), stringValue(in.CouponCode), stringValue(in.IdempotencyKey))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	o, err := r.server.orderClient.GetOrder(ctx, orderID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newOrder(o), nil
+}
+
+// stringValue dereferences an optional GraphQL string argument
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// fitsUint32 reports whether a GraphQL Int argument can be passed on as a uint32
+// without wrapping around
+func fitsUint32(n int) bool {
+	return n >= 0 && n <= math.MaxUint32
+}
 
-	return &Order{
-		ID:         o.ID,
-		CreatedAt:  o.CreatedAt,
-		TotalPrice: o.TotalPrice,
-		Products:   orderProducts,
-	}, nil
+// validQuantity reports whether a GraphQL Int argument is a quantity the
+// services accept: positive, and small enough to adjust stock by as an int32
+func validQuantity(n int) bool {
+	return n > 0 && n <= math.MaxInt32
 }
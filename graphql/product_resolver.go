@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/sdshah09/GoCore/product"
+)
+
+type productResolver struct {
+	server *Server
+}
+
+func (r *productResolver) Categories(ctx context.Context, obj *Product) ([]*Category, error) {
+	categories, err := r.server.operationCategories(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	result := []*Category{}
+	for _, slug := range obj.CategorySlugs {
+		if c, ok := categories[slug]; ok {
+			result = append(result, c)
+		}
+	}
+	return result, nil
+}
+
+// categoryLoader holds the category tree of an operation, so the categories of
+// every product in it are resolved with a single call to the product service
+type categoryLoader struct {
+	once       sync.Once
+	categories map[string]*Category
+	err        error
+}
+
+// withCategoryLoader gives every operation its own categoryLoader; the tree is
+// only read if the operation asks for the categories of a product
+func withCategoryLoader(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	return next(context.WithValue(ctx, categoryLoaderKey, &categoryLoader{}))
+}
+
+// operationCategories returns the categories by slug, read once per operation
+func (s *Server) operationCategories(ctx context.Context) (map[string]*Category, error) {
+	loader, ok := ctx.Value(categoryLoaderKey).(*categoryLoader)
+	if !ok {
+		_, categories, err := s.categoryTree(ctx)
+		return categories, err
+	}
+	loader.once.Do(func() {
+		_, loader.categories, loader.err = s.categoryTree(ctx)
+	})
+	return loader.categories, loader.err
+}
+
+// categoryTree returns the top-level categories, and every category by slug
+func (s *Server) categoryTree(ctx context.Context) ([]*Category, map[string]*Category, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	categories, err := s.productClient.GetCategories(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	roots, bySlug := newCategoryTree(categories)
+	return roots, bySlug, nil
+}
+
+// newCategory returns the category with the given slug from a fresh copy of the
+// tree, so its parent and children reflect a change just made
+func (s *Server) newCategory(ctx context.Context, c *product.Category) (*Category, error) {
+	_, categories, err := s.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if category, ok := categories[c.Slug]; ok {
+		return category, nil
+	}
+	return &Category{Slug: c.Slug, Name: c.Name, Children: []*Category{}}, nil
+}
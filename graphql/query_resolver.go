@@ -3,63 +3,62 @@ package main
 import (
 	"context"
 	"log"
+	"math"
+	"strings"
 	"time"
+
+	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/product"
 )
 
 type queryResolver struct {
 	server *Server
 }
 
-func (r *queryResolver) Accounts(ctx context.Context, pagination *PaginationInput, id *string) ([]*Account, error) {
+func (r *queryResolver) Accounts(ctx context.Context, first *int, after *string, id *string, email *string, includeDeleted *bool) (*AccountConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 	if id != nil {
-		account, err := r.server.accountClient.GetAccount(ctx, *id)
+		a, err := r.server.accountClient.GetAccount(ctx, *id)
 		if err != nil {
 			log.Println(err)
 			return nil, err
 		}
-		return []*Account{{
-			ID:   account.ID,
-			Name: account.Name,
-		}}, nil
+		return newAccountConnection([]account.Account{*a}, false), nil
 	}
-	skip, take := uint64(0), uint64(100)
-	if pagination != nil {
-		skip, take = pagination.bounds()
+	if email != nil {
+		a, err := r.server.accountClient.GetAccountByEmail(ctx, *email)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		return newAccountConnection([]account.Account{*a}, false), nil
 	}
-	accounts, err := r.server.accountClient.GetAccounts(ctx, skip, take)
+	size, err := pageSize(first)
 	if err != nil {
-		log.Println(err)
 		return nil, err
 	}
-	var result []*Account
-	for _, account := range accounts {
-		result = append(result, &Account{
-			ID:   account.ID,
-			Name: account.Name,
-		})
+	accounts, hasNextPage, err := r.server.accountClient.GetAccounts(ctx, stringValue(after), size, includeDeleted != nil && *includeDeleted)
+	if err != nil {
+		log.Println(err)
+		return nil, err
 	}
-	return result, nil
+	return newAccountConnection(accounts, hasNextPage), nil
 }
 
-func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInput, query *string, id *string) ([]*Product, error) {
+func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInput, query *string, id *string, filter *ProductFilter, sort *ProductSort) (*ProductSearchResult, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
 	// Get single product
 	if id != nil {
-		product, err := r.server.productClient.GetProduct(ctx, *id)
+		p, err := r.server.productClient.GetProduct(ctx, *id)
 		if err != nil {
 			log.Println(err)
 			return nil, err
 		}
-		return []*Product{{
-			ID:          product.ID,
-			Name:        product.Name,
-			Description: product.Description,
-			Price:       product.Price,
-		}}, nil
+		return newProductSearchResult(&product.SearchResult{Products: []product.Product{*p}, Total: 1}), nil
 	}
 
 	skip, take := uint64(0), uint64(100)
@@ -67,60 +66,115 @@ func (r *queryResolver) Products(ctx context.Context, pagination *PaginationInpu
 		skip, take = pagination.bounds()
 	}
 
-	q := ""
-	if query != nil {
-		q = *query
+	search := product.SearchFilter{Query: stringValue(query)}
+	if filter != nil {
+		search.MinPrice = filter.MinPrice
+		search.MaxPrice = filter.MaxPrice
+		search.Categories = filter.Categories
 	}
-	productList, err := r.server.productClient.GetProducts(ctx, q, nil, skip, take)
+	if sort != nil {
+		search.Sort = product.SearchSort(strings.ToLower(string(*sort)))
+	}
+	result, err := r.server.productClient.SearchProducts(ctx, search, skip, take)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
+	return newProductSearchResult(result), nil
+}
+
+func (r *queryResolver) ProductSuggestions(ctx context.Context, prefix string, first *int) ([]*ProductSuggestion, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
 
-	var products []*Product
-	for _, p := range productList {
-		products = append(products,
-			&Product{
-				ID:          p.ID,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			},
-		)
+	size, err := pageSize(first)
+	if err != nil {
+		return nil, err
 	}
+	// The product service caps the number of suggestions
+	suggestions, err := r.server.productClient.SuggestProducts(ctx, prefix, uint32(min(size, math.MaxUint32)))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	result := []*ProductSuggestion{}
+	for _, s := range suggestions {
+		result = append(result, &ProductSuggestion{ProductID: s.ProductID, Name: s.Name, Highlight: s.Highlight})
+	}
+	return result, nil
+}
 
-	return products, nil
+func (r *queryResolver) Categories(ctx context.Context) ([]*Category, error) {
+	roots, _, err := r.server.categoryTree(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return roots, nil
 }
 
-func (r *queryResolver) OrdersForAccount(ctx context.Context, accountId string) ([]*Order, error) {
+func (r *queryResolver) OrdersForAccount(ctx context.Context, accountId string, first *int, after *string) (*OrderConnection, error) {
 	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
 	defer cancel()
 
-	res, err := r.server.orderClient.GetOrdersForAccount(ctx, accountId)
+	size, err := pageSize(first)
 	if err != nil {
 		return nil, err
 	}
-	var orders []*Order
-	for _, p := range res {
-		newOrder := &Order{
-			ID:         p.ID,
-			CreatedAt:  p.CreatedAt,
-			TotalPrice: p.TotalPrice,
-		}
-		var products []*OrderProduct
-		for _, prod := range p.Products {
-			products = append(products, &OrderProduct{
-				ID:          prod.ID,
-				Name:        prod.Name,
-				Description: prod.Description,
-				Price:       prod.Price,
-				Quantity:    int(prod.Quantity),
-			})
-		}
-		newOrder.Products = products
-		orders = append(orders, newOrder)
+	orders, hasNextPage, err := r.server.orderClient.GetOrdersForAccount(ctx, accountId, stringValue(after), size)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newOrderConnection(orders, hasNextPage), nil
+}
+
+func (r *queryResolver) Order(ctx context.Context, id string) (*Order, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	o, err := r.server.orderClient.GetOrder(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newOrder(o), nil
+}
+
+func (r *queryResolver) Coupon(ctx context.Context, code string) (*Coupon, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.orderClient.GetCoupon(ctx, code)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newCoupon(c), nil
+}
+
+func (r *queryResolver) Cart(ctx context.Context, accountID string) (*Cart, error) {
+	ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
+	defer cancel()
+
+	c, err := r.server.cartClient.GetCart(ctx, accountID)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return newCart(c)
+}
+
+// pageSize converts the first argument of a connection; the services default
+// and cap the size of a page
+func pageSize(first *int) (uint64, error) {
+	if first == nil {
+		return 0, nil
+	}
+	if *first < 1 {
+		return 0, errs.InvalidArgument("first must be positive")
 	}
-	return orders, nil
+	return uint64(*first), nil
 }
 
 func (p PaginationInput) bounds() (uint64, uint64) {
@@ -0,0 +1,192 @@
+// Package money represents amounts of money exactly, as an integer number of
+// minor units (cents for USD) and an ISO 4217 currency code, so prices can be
+// added and multiplied without floating point rounding errors.
+package money
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// DefaultCurrency is used for amounts given without a currency, and for
+// prices stored before currencies were recorded
+const DefaultCurrency = "USD"
+
+var (
+	ErrInvalidAmount    = errs.InvalidArgument("invalid amount")
+	ErrInvalidCurrency  = errs.InvalidArgument("invalid currency")
+	ErrCurrencyMismatch = errs.InvalidArgument("currency mismatch")
+	ErrOverflow         = errs.InvalidArgument("amount out of range")
+)
+
+// exponents lists the ISO 4217 currencies that do not use two decimal places
+var exponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3,
+	"PYG": 0, "RWF": 0, "TND": 3, "UGX": 0, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+}
+
+// Money is an amount in the minor units of its currency, e.g. 1999 USD is $19.99
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// New returns amount minor units of currency
+func New(amount int64, currency string) (Money, error) {
+	currency, err := ParseCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+// Zero returns no money in currency
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+// Parse reads a decimal amount in major units, e.g. "19.99", and rejects
+// amounts with more decimal places than the currency has.
+func Parse(amount string, currency string) (Money, error) {
+	currency, err := ParseCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	exponent := Exponent(currency)
+	amount = strings.TrimSpace(amount)
+	negative := strings.HasPrefix(amount, "-")
+	whole, fraction, _ := strings.Cut(strings.TrimLeft(amount, "+-"), ".")
+	if whole == "" && fraction == "" || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, fmt.Errorf("%w: %q is not a decimal number", ErrInvalidAmount, amount)
+	}
+	fraction = strings.TrimRight(fraction, "0")
+	if len(fraction) > exponent {
+		return Money{}, fmt.Errorf("%w: %s has at most %d decimal places", ErrInvalidAmount, currency, exponent)
+	}
+	digits := strings.TrimLeft(whole+fraction+strings.Repeat("0", exponent-len(fraction)), "0")
+	if digits == "" {
+		return Money{Currency: currency}, nil
+	}
+	minor, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return Money{}, ErrOverflow
+	}
+	if negative {
+		minor = -minor
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// FromFloat rounds a float amount in major units to the nearest minor unit. It
+// is only meant for converting prices stored as floats before this package.
+func FromFloat(amount float64, currency string) Money {
+	scale := math.Pow10(Exponent(currency))
+	return Money{Amount: int64(math.Round(amount * scale)), Currency: currency}
+}
+
+// Exponent returns the number of decimal places of currency
+func Exponent(currency string) int {
+	if exponent, ok := exponents[currency]; ok {
+		return exponent
+	}
+	return 2
+}
+
+// WithExponent returns the currencies listed with the given number of decimal
+// places, sorted. Currencies with two decimal places are not listed.
+func WithExponent(exponent int) []string {
+	currencies := []string{}
+	for currency, e := range exponents {
+		if e == exponent {
+			currencies = append(currencies, currency)
+		}
+	}
+	sort.Strings(currencies)
+	return currencies
+}
+
+// Add returns m + other; both must be in the same currency
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: cannot add %s to %s", ErrCurrencyMismatch, other.Currency, m.Currency)
+	}
+	sum := m.Amount + other.Amount
+	if (other.Amount > 0 && sum < m.Amount) || (other.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: sum, Currency: m.Currency}, nil
+}
+
+// Subtract returns m - other; both must be in the same currency
+func (m Money) Subtract(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Multiply returns m * n, e.g. the price of n items
+func (m Money) Multiply(n int64) (Money, error) {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(n))
+	if !product.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: product.Int64(), Currency: m.Currency}, nil
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+// Decimal formats the amount in major units without the currency, e.g. "19.99"
+func (m Money) Decimal() string {
+	exponent := Exponent(m.Currency)
+	digits := strconv.FormatInt(m.Amount, 10)
+	sign := ""
+	if m.Amount < 0 {
+		sign, digits = "-", digits[1:]
+	}
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+// String formats the amount with its currency, e.g. "19.99 USD"
+func (m Money) String() string {
+	return m.Decimal() + " " + m.Currency
+}
+
+// ParseCurrency upper-cases a currency code and checks it has ISO 4217 form
+func ParseCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return "", fmt.Errorf("%w: %q is not an ISO 4217 code", ErrInvalidCurrency, currency)
+	}
+	for _, c := range currency {
+		if c < 'A' || c > 'Z' {
+			return "", fmt.Errorf("%w: %q is not an ISO 4217 code", ErrInvalidCurrency, currency)
+		}
+	}
+	return currency, nil
+}
+
+func isDigits(s string) bool {
+	for _, c := range s {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
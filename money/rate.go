@@ -0,0 +1,77 @@
+package money
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// RateDecimals is the number of decimal places exchange rates are kept with.
+// Rates are rounded to it before use, so a stored rate reproduces the amounts
+// that were converted with it.
+const RateDecimals = 10
+
+var ErrInvalidRate = errs.InvalidArgument("invalid exchange rate")
+
+// ParseRate reads a positive decimal exchange rate, e.g. "0.92"
+func ParseRate(rate string) (*big.Rat, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(rate))
+	if !ok || r.Sign() <= 0 {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidRate, rate)
+	}
+	return r, nil
+}
+
+// FormatRate formats rate as a decimal rounded to RateDecimals places, without
+// trailing zeros
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(RateDecimals)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Convert returns m in currency to, at rate units of to per unit of m's
+// currency. The result is rounded half away from zero to a minor unit of to.
+func (m Money) Convert(to string, rate *big.Rat) (Money, error) {
+	to, err := ParseCurrency(to)
+	if err != nil {
+		return Money{}, err
+	}
+	if rate == nil || rate.Sign() <= 0 {
+		return Money{}, ErrInvalidRate
+	}
+	amount := new(big.Rat).Mul(new(big.Rat).SetInt64(m.Amount), rate)
+	// Rescale from minor units of m's currency to minor units of to
+	shift := Exponent(to) - Exponent(m.Currency)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil))
+	if shift >= 0 {
+		amount.Mul(amount, scale)
+	} else {
+		amount.Quo(amount, scale)
+	}
+	rounded := roundHalfAwayFromZero(amount)
+	if !rounded.IsInt64() {
+		return Money{}, ErrOverflow
+	}
+	return Money{Amount: rounded.Int64(), Currency: to}, nil
+}
+
+func roundHalfAwayFromZero(r *big.Rat) *big.Int {
+	num := new(big.Int).Abs(r.Num())
+	// floor(|r| + 1/2) = (2*num + den) / (2*den)
+	num.Mul(num, big.NewInt(2)).Add(num, r.Denom())
+	rounded := num.Quo(num, new(big.Int).Mul(r.Denom(), big.NewInt(2)))
+	if r.Sign() < 0 {
+		rounded.Neg(rounded)
+	}
+	return rounded
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,136 @@
+package order
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/sdshah09/GoCore/product"
+)
+
+// backfillBatchSize is the number of order lines filled in per transaction
+const backfillBatchSize = 500
+
+// BackfillOrderProducts fills in the product details of the order lines stored
+// before they were snapshotted, which migration 0001 lists in
+// order_products_backfill, from the catalog as it is now. Prices are only filled
+// in if the product is priced in the currency of the order, as such orders were.
+// Lines whose product was deleted stay empty. It returns the number of lines
+// filled in, and can be run again after a failure. The list is dropped once it
+// is empty.
+func BackfillOrderProducts(ctx context.Context, url string, catalog *product.Client) (int, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+
+	var exists bool
+	if err := db.QueryRowContext(ctx, "SELECT to_regclass('order_products_backfill') IS NOT NULL").Scan(&exists); err != nil {
+		return 0, err
+	}
+	if !exists {
+		// The database was created with snapshots, or backfilled already
+		return 0, nil
+	}
+
+	filled := 0
+	for {
+		n, done, err := backfillBatch(ctx, db, catalog)
+		filled += n
+		if err != nil {
+			return filled, err
+		}
+		if done {
+			_, err = db.ExecContext(ctx, "DROP TABLE order_products_backfill")
+			return filled, err
+		}
+	}
+}
+
+type backfillLine struct {
+	orderID   string
+	productID string
+	currency  string
+}
+
+// backfillBatch fills in the next batch of lines and takes them off the list,
+// reporting whether the list is empty
+func backfillBatch(ctx context.Context, db *sql.DB, catalog *product.Client) (int, bool, error) {
+	rows, err := db.QueryContext(
+		ctx,
+		`SELECT b.order_id, b.product_id, o.currency
+    FROM order_products_backfill b JOIN orders o ON (o.id = b.order_id)
+    ORDER BY b.order_id, b.product_id
+    LIMIT $1`,
+		backfillBatchSize,
+	)
+	if err != nil {
+		return 0, false, err
+	}
+	defer rows.Close()
+	lines := []backfillLine{}
+	ids := []string{}
+	for rows.Next() {
+		l := backfillLine{}
+		if err = rows.Scan(&l.orderID, &l.productID, &l.currency); err != nil {
+			return 0, false, err
+		}
+		lines = append(lines, l)
+		ids = append(ids, l.productID)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, false, err
+	}
+	if len(lines) == 0 {
+		return 0, true, nil
+	}
+
+	products, err := catalog.GetProducts(ctx, "", ids, 0, 0)
+	if err != nil {
+		return 0, false, err
+	}
+	current := map[string]product.Product{}
+	for _, p := range products {
+		current[p.ID] = p
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+	filled := 0
+	for _, l := range lines {
+		if p, exists := current[l.productID]; exists {
+			price := sql.NullInt64{Int64: p.Price.Amount, Valid: p.Price.Currency == l.currency}
+			_, err = tx.ExecContext(
+				ctx,
+				`UPDATE order_products SET
+      name = $3,
+      description = $4,
+      price_amount = COALESCE($5, price_amount),
+      catalog_price_amount = COALESCE($5, catalog_price_amount)
+    WHERE order_id = $1 AND product_id = $2`,
+				l.orderID,
+				l.productID,
+				p.Name,
+				p.Description,
+				price,
+			)
+			if err != nil {
+				return 0, false, err
+			}
+			filled++
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"DELETE FROM order_products_backfill WHERE order_id = $1 AND product_id = $2",
+			l.orderID,
+			l.productID,
+		)
+		if err != nil {
+			return 0, false, err
+		}
+	}
+	return filled, false, tx.Commit()
+}
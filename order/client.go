@@ -3,6 +3,9 @@ package order
 import (
 	"context"
 
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
 	"github.com/sdshah09/GoCore/order/pb"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -13,8 +16,14 @@ type Client struct {
 	service pb.OrderServiceClient
 }
 
-func NewClient(url string) (*Client, error) {
-	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewClient connects to the order service at url and authenticates every call
+// with a token from tokens
+func NewClient(url string, tokens auth.TokenSource) (*Client, error) {
+	conn, err := grpc.NewClient(
+		url,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(errs.UnaryClientInterceptor, auth.UnaryClientInterceptor(tokens)),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -29,7 +38,7 @@ func (client *Client) Close() {
 	client.conn.Close()
 }
 
-func (client *Client) PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error) {
+func (client *Client) PostOrder(ctx context.Context, accountID string, region string, addressID string, products []OrderedProduct, couponCode string, idempotencyKey string) (*Order, error) {
 	// Convert OrderProduct to protobuf OrderedProduct
 	protoProducts := []*pb.PostOrderRequest_OrderedProduct{}
 	for _, p := range products {
@@ -40,60 +49,172 @@ func (client *Client) PostOrder(ctx context.Context, accountID string, products
 	}
 
 	response, err := client.service.PostOrder(ctx, &pb.PostOrderRequest{
-		AccountID: accountID,
-		Products:  protoProducts,
+		AccountID:      accountID,
+		Products:       protoProducts,
+		CouponCode:     couponCode,
+		Region:         region,
+		AddressId:      addressID,
+		IdempotencyKey: idempotencyKey,
 	})
 	if err != nil {
 		return nil, err
 	}
+	return orderFromProto(response.Order), nil
+}
 
-	// Convert protobuf response to Order
-	order := &Order{
-		ID:         response.Order.Id,
-		AccountID:  response.Order.AccountId,
-		TotalPrice: response.Order.TotalPrice,
-		CreatedAt:  response.Order.CreatedAt.AsTime(),
-		Products:   []OrderedProduct{},
+func (client *Client) GetOrder(ctx context.Context, id string) (*Order, error) {
+	res, err := client.service.GetOrder(ctx, &pb.GetOrderRequest{Id: id})
+	if err != nil {
+		return nil, err
 	}
+	return orderFromProto(res.Order), nil
+}
 
-	for _, p := range response.Order.Products {
-		order.Products = append(order.Products, OrderedProduct{
-			ID:          p.Id,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
-			Quantity:    p.Quantity,
-		})
+// GetOrderByIdempotencyKey returns the order the idempotency key of the account
+// placed, or a NotFound error if the key is unused or expired
+func (client *Client) GetOrderByIdempotencyKey(ctx context.Context, accountID string, idempotencyKey string) (*Order, error) {
+	res, err := client.service.GetOrderByIdempotencyKey(ctx, &pb.GetOrderByIdempotencyKeyRequest{
+		AccountId:      accountID,
+		IdempotencyKey: idempotencyKey,
+	})
+	if err != nil {
+		return nil, err
 	}
-
-	return order, nil
+	return orderFromProto(res.Order), nil
 }
 
-func (client *Client) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
-	res, err := client.service.GetOrdersForAccount(ctx, &pb.GetOrdersForAccountRequest{AccountID: accountID})
+// GetOrdersForAccount returns a page of the orders of the account after the
+// cursor after, and whether there is a next page
+func (client *Client) GetOrdersForAccount(ctx context.Context, accountID string, after string, first uint64) ([]Order, bool, error) {
+	res, err := client.service.GetOrdersForAccount(ctx, &pb.GetOrdersForAccountRequest{
+		AccountID: accountID,
+		After:     after,
+		First:     first,
+	})
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	orders := []Order{}
 	for _, orderProto := range res.Orders {
-		newOrder := Order{
-			ID:         orderProto.Id,
-			TotalPrice: orderProto.TotalPrice,
-			AccountID:  orderProto.AccountId,
-			CreatedAt:  orderProto.CreatedAt.AsTime(),
-		}
-		products := []OrderedProduct{}
-		for _, p := range orderProto.Products {
-			products = append(products, OrderedProduct{
-				ID:          p.Id,
-				Quantity:    p.Quantity,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+		orders = append(orders, *orderFromProto(orderProto))
+	}
+	return orders, res.HasNextPage, nil
+}
+
+func (client *Client) UpdateOrderStatus(ctx context.Context, id string, status OrderStatus) (*Order, error) {
+	res, err := client.service.UpdateOrderStatus(ctx, &pb.UpdateOrderStatusRequest{
+		Id:     id,
+		Status: string(status),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return orderFromProto(res.Order), nil
+}
+
+func (client *Client) CancelOrder(ctx context.Context, id string) (*Order, error) {
+	res, err := client.service.CancelOrder(ctx, &pb.CancelOrderRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return orderFromProto(res.Order), nil
+}
+
+func (client *Client) PostCoupon(ctx context.Context, coupon Coupon) (*Coupon, error) {
+	res, err := client.service.PostCoupon(ctx, &pb.PostCouponRequest{Coupon: couponToProto(&coupon)})
+	if err != nil {
+		return nil, err
+	}
+	c := couponFromProto(res.Coupon)
+	return &c, nil
+}
+
+func (client *Client) GetCoupon(ctx context.Context, code string) (*Coupon, error) {
+	res, err := client.service.GetCoupon(ctx, &pb.GetCouponRequest{Code: code})
+	if err != nil {
+		return nil, err
+	}
+	c := couponFromProto(res.Coupon)
+	return &c, nil
+}
+
+// orderFromProto converts a protobuf order into the domain representation
+func orderFromProto(orderProto *pb.Order) *Order {
+	order := &Order{
+		ID:            orderProto.Id,
+		AccountID:     orderProto.AccountId,
+		Subtotal:      moneyFromProto(orderProto.Subtotal),
+		Tax:           moneyFromProto(orderProto.Tax),
+		Shipping:      moneyFromProto(orderProto.Shipping),
+		TotalPrice:    moneyFromProto(orderProto.TotalPrice),
+		Region:        orderProto.Region,
+		CreatedAt:     orderProto.CreatedAt.AsTime(),
+		Status:        OrderStatus(orderProto.Status),
+		StatusHistory: []StatusChange{},
+		Products:      []OrderedProduct{},
+		CouponCode:    orderProto.CouponCode,
+		Discounts:     []Discount{},
+	}
+	for _, change := range orderProto.StatusHistory {
+		order.StatusHistory = append(order.StatusHistory, StatusChange{
+			Status:    OrderStatus(change.Status),
+			ChangedAt: change.ChangedAt.AsTime(),
+			Actor:     change.Actor,
+		})
+	}
+	for _, p := range orderProto.Products {
+		order.Products = append(order.Products, OrderedProduct{
+			ID:           p.Id,
+			Name:         p.Name,
+			Description:  p.Description,
+			Price:        moneyFromProto(p.Price),
+			CatalogPrice: moneyFromProto(p.CatalogPrice),
+			ExchangeRate: p.ExchangeRate,
+			Quantity:     p.Quantity,
+			WeightGrams:  p.WeightGrams,
+		})
+	}
+	if a := orderProto.ShippingAddress; a != nil {
+		order.ShippingAddress = &ShippingAddress{
+			AddressID:   a.AddressId,
+			Name:        a.Name,
+			Line1:       a.Line1,
+			Line2:       a.Line2,
+			City:        a.City,
+			Subdivision: a.Subdivision,
+			PostalCode:  a.PostalCode,
+			Country:     a.Country,
 		}
-		newOrder.Products = products
-		orders = append(orders, newOrder)
 	}
-	return orders, nil
+	for _, d := range orderProto.Discounts {
+		order.Discounts = append(order.Discounts, Discount{
+			ProductID:   d.ProductId,
+			Description: d.Description,
+			Amount:      moneyFromProto(d.Amount),
+		})
+	}
+	return order
+}
+
+func couponFromProto(couponProto *pb.Coupon) Coupon {
+	c := Coupon{
+		Code:        couponProto.Code,
+		Type:        DiscountType(couponProto.Type),
+		PercentOff:  couponProto.PercentOff,
+		AmountOff:   moneyFromProto(couponProto.AmountOff),
+		BuyQuantity: couponProto.BuyQuantity,
+		GetQuantity: couponProto.GetQuantity,
+		ProductID:   couponProto.ProductId,
+		MaxUses:     couponProto.MaxUses,
+		Uses:        couponProto.Uses,
+	}
+	if couponProto.ExpiresAt != nil {
+		expiresAt := couponProto.ExpiresAt.AsTime()
+		c.ExpiresAt = &expiresAt
+	}
+	return c
+}
+
+func moneyFromProto(m *pb.Money) money.Money {
+	return money.Money{Amount: m.GetAmount(), Currency: m.GetCurrency()}
 }
@@ -0,0 +1,60 @@
+// Command backfill fills in the product details of the order lines stored before
+// they were snapshotted, from the catalog. Run it once after migration 0001; the
+// order service keeps serving meanwhile.
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/order"
+	"github.com/sdshah09/GoCore/product"
+)
+
+type Config struct {
+	DBHost     string `envconfig:"DB_HOST"`
+	DBPort     string `envconfig:"DB_PORT"`
+	DBName     string `envconfig:"DB_NAME"`
+	DBUser     string `envconfig:"DB_USER"`
+	DBPassword string `envconfig:"DB_PASSWORD"`
+	ProductURL string `envconfig:"PRODUCT_SERVICE_URL"`
+	JWTSecret  string `envconfig:"JWT_SECRET" required:"true"`
+}
+
+func (c Config) DatabaseURL() string {
+	return fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
+		c.DBUser,
+		url.QueryEscape(c.DBPassword),
+		c.DBHost,
+		c.DBPort,
+		c.DBName,
+	)
+}
+
+func main() {
+	var cfg Config
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	tokens, err := auth.NewTokenManager([]byte(cfg.JWTSecret), 0, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	productClient, err := product.NewClient(cfg.ProductURL, tokens.ServiceTokens("order"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer productClient.Close()
+
+	filled, err := order.BackfillOrderProducts(context.Background(), cfg.DatabaseURL(), productClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Filled in %d order lines", filled)
+}
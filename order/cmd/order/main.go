@@ -8,18 +8,33 @@ import (
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/money"
 	"github.com/sdshah09/GoCore/order"
+	"github.com/sdshah09/GoCore/product"
 	"github.com/tinrab/retry"
 )
 
 type Config struct {
-	DBHost      string `envconfig:"DB_HOST"`
-	DBPort      string `envconfig:"DB_PORT"`
-	DBName      string `envconfig:"DB_NAME"`
-	DBUser      string `envconfig:"DB_USER"`
-	DBPassword  string `envconfig:"DB_PASSWORD"`
-	AccountURL  string `envconfig:"ACCOUNT_SERVICE_URL"`
-	ProductURL  string `envconfig:"PRODUCT_SERVICE_URL"`
+	DBHost     string `envconfig:"DB_HOST"`
+	DBPort     string `envconfig:"DB_PORT"`
+	DBName     string `envconfig:"DB_NAME"`
+	DBUser     string `envconfig:"DB_USER"`
+	DBPassword string `envconfig:"DB_PASSWORD"`
+	AccountURL string `envconfig:"ACCOUNT_SERVICE_URL"`
+	ProductURL string `envconfig:"PRODUCT_SERVICE_URL"`
+	HealthPort int    `envconfig:"HEALTH_PORT" default:"8080"`
+	// ExchangeRatesFile is a JSON file of static rates, see order.LoadStaticRates
+	ExchangeRatesFile string `envconfig:"EXCHANGE_RATES_FILE"`
+	// PricingRulesFile is a YAML or JSON file of tax and shipping rules, see
+	// order.LoadPricingRules
+	PricingRulesFile string `envconfig:"PRICING_RULES_FILE"`
+	// Repository selects the storage backend: "postgres" (default) or "memory"
+	Repository string `envconfig:"REPOSITORY" default:"postgres"`
+	// JWTSecret verifies the access tokens of callers and signs the tokens the
+	// service calls other services with
+	JWTSecret string `envconfig:"JWT_SECRET" required:"true"`
 }
 
 func (c Config) DatabaseURL() string {
@@ -39,15 +54,20 @@ func main() {
 		log.Fatal(err)
 	}
 	var repo order.Repository
-	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
-		repo, err = order.NewPostgresRepository(cfg.DatabaseURL())
-		if err != nil {
-			log.Println(err)
-		}
-		return
-	})
+	if cfg.Repository == "memory" {
+		log.Println("Using in-memory repository, data is lost on restart")
+		repo = order.NewMemoryRepository()
+	} else {
+		retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+			repo, err = order.NewPostgresRepository(cfg.DatabaseURL())
+			if err != nil {
+				log.Println(err)
+			}
+			return
+		})
+	}
 	defer repo.Close()
-	
+
 	// Start HTTP health check server
 	go func() {
 		http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
@@ -64,11 +84,49 @@ func main() {
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("OK"))
 		})
-		log.Println("Health check server listening on :8080")
-		log.Fatal(http.ListenAndServe(":8080", nil))
+		log.Printf("Health check server listening on :%d", cfg.HealthPort)
+		log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", cfg.HealthPort), nil))
 	}()
-	
+
+	tokens, err := auth.NewTokenManager([]byte(cfg.JWTSecret), 0, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	serviceTokens := tokens.ServiceTokens("order")
+	accountClient, err := account.NewClient(cfg.AccountURL, serviceTokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer accountClient.Close()
+	productClient, err := product.NewClient(cfg.ProductURL, serviceTokens)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer productClient.Close()
+
+	var rates order.ExchangeRateProvider
+	if cfg.ExchangeRatesFile != "" {
+		rates, err = order.LoadStaticRates(cfg.ExchangeRatesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Println("No EXCHANGE_RATES_FILE set, orders can only be placed in the currency of their products")
+		rates = order.NewStaticRates(money.DefaultCurrency, nil)
+	}
+
+	var pricing *order.PricingRules
+	if cfg.PricingRulesFile != "" {
+		pricing, err = order.LoadPricingRules(cfg.PricingRulesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		log.Println("No PRICING_RULES_FILE set, orders are charged no tax or shipping")
+		pricing = order.NewPricingRules()
+	}
+
 	log.Println("Listening on 8083...")
-	s := order.NewService(repo)
-	log.Fatal(order.ListenGRPC(s, cfg.AccountURL, cfg.ProductURL, 8083))
+	s := order.NewService(repo, productClient, rates, pricing, pricing)
+	log.Fatal(order.ListenGRPC(s, tokens, accountClient, productClient, 8083))
 }
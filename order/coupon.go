@@ -0,0 +1,181 @@
+package order
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+)
+
+type DiscountType string
+
+const (
+	// DiscountPercent takes PercentOff percent off the price
+	DiscountPercent DiscountType = "percent"
+	// DiscountFixed takes AmountOff off the order, or off each unit of the product
+	DiscountFixed DiscountType = "fixed"
+	// DiscountBuyXGetY gives GetQuantity units free for every BuyQuantity bought
+	DiscountBuyXGetY DiscountType = "buy_x_get_y"
+)
+
+var (
+	ErrCouponNotFound      = errs.NotFound("coupon not found")
+	ErrCouponUnavailable   = errs.FailedPrecondition("coupon is expired or fully used")
+	ErrCouponNotApplicable = errs.FailedPrecondition("coupon does not apply to this order")
+	ErrInvalidCoupon       = errs.InvalidArgument("invalid coupon")
+)
+
+// Coupon is a promotion code that discounts an order. A coupon with a ProductID
+// only discounts that product; otherwise it applies to the whole order.
+type Coupon struct {
+	Code        string
+	Type        DiscountType
+	PercentOff  uint32
+	AmountOff   money.Money
+	BuyQuantity uint32
+	GetQuantity uint32
+	ProductID   string
+	MaxUses     uint32 // 0 means unlimited
+	Uses        uint32
+	ExpiresAt   *time.Time
+}
+
+// Discount is one line of the discount breakdown of an order. ProductID is empty
+// for discounts on the whole order.
+type Discount struct {
+	ProductID   string
+	Description string
+	Amount      money.Money
+}
+
+// normalizeCouponCode makes coupon codes case insensitive
+func normalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks the coupon is well formed and normalizes its code
+func (c *Coupon) Validate() error {
+	c.Code = normalizeCouponCode(c.Code)
+	if c.Code == "" || len(c.Code) > 64 {
+		return fmt.Errorf("%w: code must be 1 to 64 characters", ErrInvalidCoupon)
+	}
+	switch c.Type {
+	case DiscountPercent:
+		if c.PercentOff == 0 || c.PercentOff > 100 {
+			return fmt.Errorf("%w: percent off must be between 1 and 100", ErrInvalidCoupon)
+		}
+	case DiscountFixed:
+		amountOff, err := money.New(c.AmountOff.Amount, c.AmountOff.Currency)
+		if err != nil {
+			return err
+		}
+		if amountOff.Amount <= 0 {
+			return fmt.Errorf("%w: amount off must be positive", ErrInvalidCoupon)
+		}
+		c.AmountOff = amountOff
+	case DiscountBuyXGetY:
+		if c.BuyQuantity == 0 || c.GetQuantity == 0 {
+			return fmt.Errorf("%w: buy and get quantities must be positive", ErrInvalidCoupon)
+		}
+	default:
+		return fmt.Errorf("%w: unknown discount type %q", ErrInvalidCoupon, c.Type)
+	}
+	return nil
+}
+
+// Redeemable reports whether the coupon has not expired and has uses left
+func (c *Coupon) Redeemable(now time.Time) bool {
+	if c.ExpiresAt != nil && !now.Before(*c.ExpiresAt) {
+		return false
+	}
+	return c.MaxUses == 0 || c.Uses < c.MaxUses
+}
+
+// Discounts computes the discounts the coupon gives on products, which are
+// priced in the order currency. amountOff is the AmountOff of a fixed coupon
+// converted into that currency, and zero in that currency for other coupons.
+// Discounts never exceed the price they apply to.
+func (c *Coupon) Discounts(products []OrderedProduct, amountOff money.Money) ([]Discount, error) {
+	currency := amountOff.Currency
+	if c.ProductID == "" && c.Type != DiscountBuyXGetY {
+		subtotal := money.Zero(currency)
+		for _, p := range products {
+			linePrice, err := p.Price.Multiply(int64(p.Quantity))
+			if err != nil {
+				return nil, err
+			}
+			if subtotal, err = subtotal.Add(linePrice); err != nil {
+				return nil, err
+			}
+		}
+		amount, err := c.discount(subtotal, amountOff)
+		if err != nil {
+			return nil, err
+		}
+		return []Discount{{Description: c.describe(), Amount: amount}}, nil
+	}
+
+	discounts := []Discount{}
+	for _, p := range products {
+		if c.ProductID != "" && p.ID != c.ProductID {
+			continue
+		}
+		var amount money.Money
+		var err error
+		switch c.Type {
+		case DiscountBuyXGetY:
+			free := p.Quantity / (c.BuyQuantity + c.GetQuantity) * c.GetQuantity
+			amount, err = p.Price.Multiply(int64(free))
+		case DiscountFixed:
+			// Fixed product discounts apply to each unit
+			amount, err = c.discount(p.Price, amountOff)
+			if err == nil {
+				amount, err = amount.Multiply(int64(p.Quantity))
+			}
+		default:
+			linePrice, lineErr := p.Price.Multiply(int64(p.Quantity))
+			if lineErr != nil {
+				return nil, lineErr
+			}
+			amount, err = c.discount(linePrice, amountOff)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if amount.Amount > 0 {
+			discounts = append(discounts, Discount{ProductID: p.ID, Description: c.describe(), Amount: amount})
+		}
+	}
+	if len(discounts) == 0 {
+		return nil, ErrCouponNotApplicable
+	}
+	return discounts, nil
+}
+
+// discount returns the percent or fixed discount on price, capped at price
+func (c *Coupon) discount(price money.Money, amountOff money.Money) (money.Money, error) {
+	if c.Type == DiscountFixed {
+		if amountOff.Amount > price.Amount {
+			return price, nil
+		}
+		return amountOff, nil
+	}
+	return price.Convert(price.Currency, big.NewRat(int64(c.PercentOff), 100))
+}
+
+func (c *Coupon) describe() string {
+	switch c.Type {
+	case DiscountPercent:
+		return fmt.Sprintf("%s: %d%% off", c.Code, c.PercentOff)
+	case DiscountFixed:
+		if c.ProductID != "" {
+			return fmt.Sprintf("%s: %s off each", c.Code, c.AmountOff)
+		}
+		return fmt.Sprintf("%s: %s off", c.Code, c.AmountOff)
+	default:
+		return fmt.Sprintf("%s: buy %d get %d free", c.Code, c.BuyQuantity, c.GetQuantity)
+	}
+}
@@ -0,0 +1,134 @@
+package order
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/sdshah09/GoCore/money"
+)
+
+func TestCouponDiscounts(t *testing.T) {
+	usd := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "USD"} }
+	products := []OrderedProduct{
+		{ID: "a", Quantity: 2, Price: usd(1999)},
+		{ID: "b", Quantity: 7, Price: usd(500)},
+	}
+	tests := []struct {
+		name      string
+		coupon    Coupon
+		amountOff money.Money
+		want      []Discount
+		wantErr   error
+	}{
+		{
+			name:      "percent off the order",
+			coupon:    Coupon{Code: "TEN", Type: DiscountPercent, PercentOff: 10},
+			amountOff: usd(0),
+			// 10% of 7498, rounded half away from zero
+			want: []Discount{{Description: "TEN: 10% off", Amount: usd(750)}},
+		},
+		{
+			name:      "percent off a product",
+			coupon:    Coupon{Code: "HALF", Type: DiscountPercent, PercentOff: 50, ProductID: "a"},
+			amountOff: usd(0),
+			want:      []Discount{{ProductID: "a", Description: "HALF: 50% off", Amount: usd(1999)}},
+		},
+		{
+			name:      "fixed amount off the order",
+			coupon:    Coupon{Code: "FIVE", Type: DiscountFixed, AmountOff: usd(500)},
+			amountOff: usd(500),
+			want:      []Discount{{Description: "FIVE: 5.00 USD off", Amount: usd(500)}},
+		},
+		{
+			name:      "fixed amount off the order is capped at the subtotal",
+			coupon:    Coupon{Code: "BIG", Type: DiscountFixed, AmountOff: usd(100000)},
+			amountOff: usd(100000),
+			want:      []Discount{{Description: "BIG: 1000.00 USD off", Amount: usd(7498)}},
+		},
+		{
+			name:      "fixed amount off each unit of a product",
+			coupon:    Coupon{Code: "ONE", Type: DiscountFixed, AmountOff: usd(100), ProductID: "b"},
+			amountOff: usd(100),
+			want:      []Discount{{ProductID: "b", Description: "ONE: 1.00 USD off each", Amount: usd(700)}},
+		},
+		{
+			name:      "fixed amount off each unit is capped at the unit price",
+			coupon:    Coupon{Code: "TEN", Type: DiscountFixed, AmountOff: usd(1000), ProductID: "b"},
+			amountOff: usd(1000),
+			want:      []Discount{{ProductID: "b", Description: "TEN: 10.00 USD off each", Amount: usd(3500)}},
+		},
+		{
+			name:      "fixed amount converted into the order currency",
+			coupon:    Coupon{Code: "FIVE", Type: DiscountFixed, AmountOff: money.Money{Amount: 500, Currency: "EUR"}},
+			amountOff: usd(540),
+			want:      []Discount{{Description: "FIVE: 5.00 EUR off", Amount: usd(540)}},
+		},
+		{
+			name:      "buy two get one free on every product",
+			coupon:    Coupon{Code: "B2G1", Type: DiscountBuyXGetY, BuyQuantity: 2, GetQuantity: 1},
+			amountOff: usd(0),
+			// 7 units of b make two full sets of three; 2 units of a make none
+			want: []Discount{{ProductID: "b", Description: "B2G1: buy 2 get 1 free", Amount: usd(1000)}},
+		},
+		{
+			name:      "buy one get one free on a product",
+			coupon:    Coupon{Code: "BOGO", Type: DiscountBuyXGetY, BuyQuantity: 1, GetQuantity: 1, ProductID: "a"},
+			amountOff: usd(0),
+			want:      []Discount{{ProductID: "a", Description: "BOGO: buy 1 get 1 free", Amount: usd(1999)}},
+		},
+		{
+			name:      "buy X get Y without enough units",
+			coupon:    Coupon{Code: "B5G1", Type: DiscountBuyXGetY, BuyQuantity: 5, GetQuantity: 1, ProductID: "a"},
+			amountOff: usd(0),
+			wantErr:   ErrCouponNotApplicable,
+		},
+		{
+			name:      "product not in the order",
+			coupon:    Coupon{Code: "HALF", Type: DiscountPercent, PercentOff: 50, ProductID: "c"},
+			amountOff: usd(0),
+			wantErr:   ErrCouponNotApplicable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.coupon.Discounts(products, tt.amountOff)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Discounts() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Discounts() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCouponValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		coupon   Coupon
+		wantCode string
+		wantErr  bool
+	}{
+		{"percent", Coupon{Code: " save10 ", Type: DiscountPercent, PercentOff: 10}, "SAVE10", false},
+		{"percent over 100", Coupon{Code: "X", Type: DiscountPercent, PercentOff: 101}, "X", true},
+		{"percent of zero", Coupon{Code: "X", Type: DiscountPercent}, "X", true},
+		{"fixed", Coupon{Code: "X", Type: DiscountFixed, AmountOff: money.Money{Amount: 500, Currency: "usd"}}, "X", false},
+		{"fixed of zero", Coupon{Code: "X", Type: DiscountFixed, AmountOff: money.Money{Currency: "USD"}}, "X", true},
+		{"buy X get Y", Coupon{Code: "X", Type: DiscountBuyXGetY, BuyQuantity: 2, GetQuantity: 1}, "X", false},
+		{"buy X get none", Coupon{Code: "X", Type: DiscountBuyXGetY, BuyQuantity: 2}, "X", true},
+		{"empty code", Coupon{Code: "  ", Type: DiscountPercent, PercentOff: 10}, "", true},
+		{"unknown type", Coupon{Code: "X", Type: "free"}, "X", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.coupon.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, want error %v", err, tt.wantErr)
+			}
+			if err == nil && tt.coupon.Code != tt.wantCode {
+				t.Errorf("Code = %q, want %q", tt.coupon.Code, tt.wantCode)
+			}
+		})
+	}
+}
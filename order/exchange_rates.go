@@ -0,0 +1,85 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+)
+
+var ErrNoExchangeRate = errs.FailedPrecondition("no exchange rate")
+
+// ExchangeRateProvider supplies the rates used to convert product prices into
+// the currency an order is placed in
+type ExchangeRateProvider interface {
+	// Rate returns how many units of currency to one unit of currency from buys
+	Rate(ctx context.Context, from string, to string) (*big.Rat, error)
+}
+
+// staticRates converts between currencies with fixed rates against a base currency
+type staticRates struct {
+	rates map[string]*big.Rat
+}
+
+// NewStaticRates returns a provider with fixed rates, where rates[c] is how many
+// units of c one unit of base buys. Cross rates are derived through base.
+func NewStaticRates(base string, rates map[string]*big.Rat) ExchangeRateProvider {
+	all := map[string]*big.Rat{base: big.NewRat(1, 1)}
+	for currency, rate := range rates {
+		all[currency] = rate
+	}
+	return &staticRates{rates: all}
+}
+
+// LoadStaticRates reads fixed rates from a JSON file such as
+//
+//	{"base": "USD", "rates": {"EUR": "0.92", "JPY": "151.3"}}
+//
+// Rates may be given as strings or numbers; both are read exactly.
+func LoadStaticRates(path string) (ExchangeRateProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file struct {
+		Base  string                 `json:"base"`
+		Rates map[string]json.Number `json:"rates"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	base, err := money.ParseCurrency(file.Base)
+	if err != nil {
+		return nil, fmt.Errorf("%s: base: %w", path, err)
+	}
+	rates := map[string]*big.Rat{}
+	for code, value := range file.Rates {
+		currency, err := money.ParseCurrency(code)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if rates[currency], err = money.ParseRate(value.String()); err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", path, currency, err)
+		}
+	}
+	return NewStaticRates(base, rates), nil
+}
+
+func (r *staticRates) Rate(ctx context.Context, from string, to string) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+	fromRate, ok := r.rates[from]
+	if !ok {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoExchangeRate, from, to)
+	}
+	toRate, ok := r.rates[to]
+	if !ok {
+		return nil, fmt.Errorf("%w from %s to %s", ErrNoExchangeRate, from, to)
+	}
+	return new(big.Rat).Quo(toRate, fromRate), nil
+}
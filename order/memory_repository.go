@@ -0,0 +1,169 @@
+package order
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// memoryRepository keeps orders in process memory. It is meant for tests and
+// local demos; nothing survives a restart.
+type memoryRepository struct {
+	mu              sync.RWMutex
+	orders          map[string]Order
+	idempotencyKeys map[idempotencyKeyID]idempotencyKey
+	coupons         map[string]Coupon
+}
+
+// idempotencyKeyID scopes an idempotency key to an account
+type idempotencyKeyID struct {
+	accountID string
+	key       string
+}
+
+type idempotencyKey struct {
+	orderID   string
+	expiresAt time.Time
+}
+
+func NewMemoryRepository() Repository {
+	return &memoryRepository{
+		orders:          map[string]Order{},
+		idempotencyKeys: map[idempotencyKeyID]idempotencyKey{},
+		coupons:         map[string]Coupon{},
+	}
+}
+
+func (r *memoryRepository) Close() {
+}
+
+func (r *memoryRepository) Ping() error {
+	return nil
+}
+
+func (r *memoryRepository) PutOrder(ctx context.Context, o Order) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.orders[o.ID]; exists {
+		return errs.AlreadyExists("order already exists")
+	}
+	if o.CouponCode != "" {
+		coupon, exists := r.coupons[o.CouponCode]
+		if !exists || !coupon.Redeemable(time.Now()) {
+			return ErrCouponUnavailable
+		}
+		coupon.Uses++
+		r.coupons[o.CouponCode] = coupon
+	}
+	r.orders[o.ID] = copyOrder(o)
+	return nil
+}
+
+func (r *memoryRepository) GetOrderByID(ctx context.Context, id string) (*Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	o, exists := r.orders[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	o = copyOrder(o)
+	return &o, nil
+}
+
+func (r *memoryRepository) GetOrdersForAccount(ctx context.Context, accountID string, afterID string, limit uint64) ([]Order, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	orders := []Order{}
+	for _, o := range r.orders {
+		if o.AccountID == accountID && (afterID == "" || o.ID > afterID) {
+			orders = append(orders, copyOrder(o))
+		}
+	}
+	// Same order as the Postgres repository
+	sort.Slice(orders, func(i, j int) bool {
+		return orders[i].ID < orders[j].ID
+	})
+	if limit < uint64(len(orders)) {
+		orders = orders[:limit]
+	}
+	return orders, nil
+}
+
+func (r *memoryRepository) UpdateOrderStatus(ctx context.Context, id string, from OrderStatus, change StatusChange) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, exists := r.orders[id]
+	if !exists {
+		return ErrNotFound
+	}
+	if o.Status != from {
+		return ErrStatusChanged
+	}
+	o.Status = change.Status
+	o.StatusHistory = append(o.StatusHistory, change)
+	r.orders[id] = o
+	return nil
+}
+
+func (r *memoryRepository) ReserveIdempotencyKey(ctx context.Context, accountID string, key string, orderID string, ttl time.Duration) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	id := idempotencyKeyID{accountID: accountID, key: key}
+	if existing, exists := r.idempotencyKeys[id]; exists && existing.expiresAt.After(time.Now()) {
+		return existing.orderID, nil
+	}
+	r.idempotencyKeys[id] = idempotencyKey{orderID: orderID, expiresAt: time.Now().Add(ttl)}
+	return orderID, nil
+}
+
+func (r *memoryRepository) ReleaseIdempotencyKey(ctx context.Context, accountID string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.idempotencyKeys, idempotencyKeyID{accountID: accountID, key: key})
+	return nil
+}
+
+func (r *memoryRepository) GetIdempotencyKey(ctx context.Context, accountID string, key string) (string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	existing, exists := r.idempotencyKeys[idempotencyKeyID{accountID: accountID, key: key}]
+	if !exists || !existing.expiresAt.After(time.Now()) {
+		return "", ErrNotFound
+	}
+	return existing.orderID, nil
+}
+
+func (r *memoryRepository) PutCoupon(ctx context.Context, c Coupon) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.coupons[c.Code]; exists {
+		return errs.AlreadyExists("coupon already exists")
+	}
+	r.coupons[c.Code] = c
+	return nil
+}
+
+func (r *memoryRepository) GetCoupon(ctx context.Context, code string) (*Coupon, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	c, exists := r.coupons[code]
+	if !exists {
+		return nil, ErrCouponNotFound
+	}
+	return &c, nil
+}
+
+// copyOrder copies the slices of an order so callers cannot modify stored state
+func copyOrder(o Order) Order {
+	o.Products = append([]OrderedProduct{}, o.Products...)
+	o.StatusHistory = append([]StatusChange{}, o.StatusHistory...)
+	o.Discounts = append([]Discount{}, o.Discounts...)
+	if o.ShippingAddress != nil {
+		address := *o.ShippingAddress
+		o.ShippingAddress = &address
+	}
+	return o
+}
@@ -0,0 +1,23 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+INSERT INTO schema_migrations (version) VALUES (1);
+
+ALTER TABLE order_products ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE order_products ADD COLUMN description TEXT NOT NULL DEFAULT '';
+
+-- Lines stored until now have no details; the backfill command fills them in
+-- from the catalog and takes them off this list
+CREATE TABLE order_products_backfill (
+    product_id CHAR(27) NOT NULL,
+    order_id CHAR(27) NOT NULL,
+    PRIMARY KEY (product_id, order_id),
+    FOREIGN KEY (product_id, order_id) REFERENCES order_products (product_id, order_id) ON DELETE CASCADE
+);
+INSERT INTO order_products_backfill (product_id, order_id)
+    SELECT product_id, order_id FROM order_products;
+
+COMMIT;
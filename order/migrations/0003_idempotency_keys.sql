@@ -0,0 +1,13 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (3);
+
+CREATE TABLE idempotency_keys (
+    account_id CHAR(27) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    order_id CHAR(27) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (account_id, key)
+);
+
+COMMIT;
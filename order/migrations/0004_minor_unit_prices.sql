@@ -0,0 +1,16 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (4);
+
+-- MONEY amounts are converted as USD, the only currency used before. Lines had
+-- no price stored.
+ALTER TABLE orders ADD COLUMN total_price_amount BIGINT;
+ALTER TABLE orders ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'USD';
+UPDATE orders SET total_price_amount = round(total_price::numeric * 100)::bigint;
+ALTER TABLE orders DROP COLUMN total_price;
+ALTER TABLE orders ALTER COLUMN total_price_amount SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN currency DROP DEFAULT;
+
+ALTER TABLE order_products ADD COLUMN price_amount BIGINT NOT NULL DEFAULT 0;
+
+COMMIT;
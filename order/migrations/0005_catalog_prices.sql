@@ -0,0 +1,15 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (5);
+
+-- Earlier orders were never converted, so their catalog price is their price at
+-- a rate of 1
+ALTER TABLE order_products ADD COLUMN catalog_price_amount BIGINT;
+ALTER TABLE order_products ADD COLUMN catalog_currency CHAR(3);
+ALTER TABLE order_products ADD COLUMN exchange_rate NUMERIC NOT NULL DEFAULT 1;
+UPDATE order_products op SET catalog_price_amount = op.price_amount, catalog_currency = o.currency
+    FROM orders o WHERE o.id = op.order_id;
+ALTER TABLE order_products ALTER COLUMN catalog_price_amount SET NOT NULL;
+ALTER TABLE order_products ALTER COLUMN catalog_currency SET NOT NULL;
+
+COMMIT;
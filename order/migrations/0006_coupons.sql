@@ -0,0 +1,31 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (6);
+
+CREATE TABLE coupons (
+    code VARCHAR(64) PRIMARY KEY,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('percent', 'fixed', 'buy_x_get_y')),
+    percent_off INT NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+    amount_off BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3),
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    product_id VARCHAR(27) NOT NULL DEFAULT '',
+    max_uses INT NOT NULL DEFAULT 0,
+    uses INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+ALTER TABLE orders ADD COLUMN coupon_code VARCHAR(64) REFERENCES coupons (code);
+
+CREATE TABLE order_discounts (
+    id BIGSERIAL PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id VARCHAR(27) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    amount BIGINT NOT NULL
+);
+
+CREATE INDEX order_discounts_order_id_idx ON order_discounts (order_id);
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (7);
+
+-- Earlier orders had no tax or shipping, so their subtotal is their total plus
+-- their discounts
+ALTER TABLE orders ADD COLUMN subtotal_amount BIGINT;
+ALTER TABLE orders ADD COLUMN tax_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN shipping_amount BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN region VARCHAR(16) NOT NULL DEFAULT '';
+ALTER TABLE order_products ADD COLUMN weight_grams INT NOT NULL DEFAULT 0;
+UPDATE orders o SET subtotal_amount = o.total_price_amount
+    + COALESCE((SELECT SUM(d.amount) FROM order_discounts d WHERE d.order_id = o.id), 0);
+ALTER TABLE orders ALTER COLUMN subtotal_amount SET NOT NULL;
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+INSERT INTO schema_migrations (version) VALUES (8);
+
+CREATE TABLE order_shipping_addresses (
+    order_id CHAR(27) PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    address_id CHAR(27) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    line1 VARCHAR(128) NOT NULL,
+    line2 VARCHAR(128) NOT NULL DEFAULT '',
+    city VARCHAR(64) NOT NULL,
+    subdivision VARCHAR(3) NOT NULL DEFAULT '',
+    postal_code VARCHAR(16) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL
+);
+
+COMMIT;
@@ -0,0 +1,176 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+	"gopkg.in/yaml.v3"
+)
+
+// anyRegion is the region key of rules that apply where no other rule does
+const anyRegion = "*"
+
+var ErrNoShippingRate = errs.FailedPrecondition("no shipping rate")
+
+// TaxCalculator computes the tax due on the taxable amount of an order
+type TaxCalculator interface {
+	Tax(ctx context.Context, region string, taxable money.Money) (money.Money, error)
+}
+
+// ShippingCalculator computes the shipping price of an order by weight. The price
+// may be in any currency; it is converted into the currency of the order.
+type ShippingCalculator interface {
+	Shipping(ctx context.Context, region string, weightGrams uint64) (money.Money, error)
+}
+
+// PricingRules is a table of tax rates and shipping prices keyed by region, and
+// for shipping also by weight. It implements TaxCalculator and ShippingCalculator.
+//
+// Regions are matched most specific first: an order to "US-CA" uses the rules of
+// "US-CA", then "US", then "*". Without a matching tax rule no tax is charged;
+// without any shipping rules shipping is free.
+type PricingRules struct {
+	taxRates      map[string]*big.Rat
+	shippingRates map[string][]shippingRate
+	currency      string
+}
+
+type shippingRate struct {
+	maxWeightGrams uint64 // 0 means no limit
+	price          money.Money
+}
+
+// pricingRulesFile is the YAML (or JSON) format read by LoadPricingRules
+type pricingRulesFile struct {
+	// Currency of the shipping prices
+	Currency string `yaml:"currency"`
+	Tax      []struct {
+		Region string `yaml:"region"`
+		Rate   string `yaml:"rate"`
+	} `yaml:"tax"`
+	Shipping []struct {
+		Region         string `yaml:"region"`
+		MaxWeightGrams uint64 `yaml:"max_weight_grams"`
+		Price          string `yaml:"price"`
+	} `yaml:"shipping"`
+}
+
+// NewPricingRules returns rules that charge no tax and no shipping
+func NewPricingRules() *PricingRules {
+	return &PricingRules{
+		taxRates:      map[string]*big.Rat{},
+		shippingRates: map[string][]shippingRate{},
+		currency:      money.DefaultCurrency,
+	}
+}
+
+// LoadPricingRules reads rules from a YAML or JSON file such as
+//
+//	currency: USD
+//	tax:
+//	  - {region: US-CA, rate: "0.0725"}
+//	  - {region: DE, rate: "0.19"}
+//	shipping:
+//	  - {region: US, max_weight_grams: 1000, price: "5.00"}
+//	  - {region: US, price: "12.50"}
+//	  - {region: "*", price: "25.00"}
+func LoadPricingRules(path string) (*PricingRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file pricingRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	rules := NewPricingRules()
+	if file.Currency != "" {
+		if rules.currency, err = money.ParseCurrency(file.Currency); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	}
+	for _, rule := range file.Tax {
+		rate, ok := new(big.Rat).SetString(rule.Rate)
+		if !ok || rate.Sign() < 0 {
+			return nil, fmt.Errorf("%s: tax rate %q of region %q is not a non-negative decimal", path, rule.Rate, rule.Region)
+		}
+		rules.taxRates[normalizeRegion(rule.Region)] = rate
+	}
+	for _, rule := range file.Shipping {
+		price, err := money.Parse(rule.Price, rules.currency)
+		if err != nil {
+			return nil, fmt.Errorf("%s: shipping price of region %q: %w", path, rule.Region, err)
+		}
+		region := normalizeRegion(rule.Region)
+		rules.shippingRates[region] = append(rules.shippingRates[region], shippingRate{rule.MaxWeightGrams, price})
+	}
+	for _, rates := range rules.shippingRates {
+		// Try the lightest weight class first and the unlimited one last
+		sort.SliceStable(rates, func(i, j int) bool {
+			if rates[i].maxWeightGrams == 0 || rates[j].maxWeightGrams == 0 {
+				return rates[j].maxWeightGrams == 0 && rates[i].maxWeightGrams != 0
+			}
+			return rates[i].maxWeightGrams < rates[j].maxWeightGrams
+		})
+	}
+	return rules, nil
+}
+
+func (rules *PricingRules) Tax(ctx context.Context, region string, taxable money.Money) (money.Money, error) {
+	for _, key := range regionKeys(region) {
+		rate, ok := rules.taxRates[key]
+		if !ok {
+			continue
+		}
+		if rate.Sign() == 0 {
+			return money.Zero(taxable.Currency), nil
+		}
+		return taxable.Convert(taxable.Currency, rate)
+	}
+	return money.Zero(taxable.Currency), nil
+}
+
+func (rules *PricingRules) Shipping(ctx context.Context, region string, weightGrams uint64) (money.Money, error) {
+	if len(rules.shippingRates) == 0 {
+		return money.Zero(rules.currency), nil
+	}
+	for _, key := range regionKeys(region) {
+		rates, ok := rules.shippingRates[key]
+		if !ok {
+			continue
+		}
+		for _, rate := range rates {
+			if rate.maxWeightGrams == 0 || weightGrams <= rate.maxWeightGrams {
+				return rate.price, nil
+			}
+		}
+		break
+	}
+	return money.Money{}, fmt.Errorf("%w for region %q and %d grams", ErrNoShippingRate, region, weightGrams)
+}
+
+func normalizeRegion(region string) string {
+	return strings.ToUpper(strings.TrimSpace(region))
+}
+
+// regionKeys returns the keys rules for region are looked up by, most specific
+// first, e.g. "US-CA", "US", "*"
+func regionKeys(region string) []string {
+	region = normalizeRegion(region)
+	keys := []string{}
+	for region != "" {
+		keys = append(keys, region)
+		i := strings.LastIndex(region, "-")
+		if i < 0 {
+			break
+		}
+		region = region[:i]
+	}
+	return append(keys, anyRegion)
+}
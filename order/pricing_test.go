@@ -0,0 +1,143 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/sdshah09/GoCore/money"
+)
+
+const testPricingRules = `
+currency: USD
+tax:
+  - {region: US-CA, rate: "0.0725"}
+  - {region: DE, rate: "0.19"}
+  - {region: HK, rate: "0"}
+shipping:
+  - {region: US, price: "19.00"}
+  - {region: US, max_weight_grams: 5000, price: "9.50"}
+  - {region: US, max_weight_grams: 1000, price: "5.00"}
+  - {region: US-AK, max_weight_grams: 1000, price: "30.00"}
+  - {region: "*", max_weight_grams: 1000, price: "15.00"}
+  - {region: "*", price: "40.00"}
+`
+
+func loadTestPricingRules(t *testing.T, rules string) *PricingRules {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "pricing_rules.yaml")
+	if err := os.WriteFile(path, []byte(rules), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	pricing, err := LoadPricingRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pricing
+}
+
+func TestPricingRulesTax(t *testing.T) {
+	pricing := loadTestPricingRules(t, testPricingRules)
+	tests := []struct {
+		name    string
+		region  string
+		taxable money.Money
+		want    money.Money
+	}{
+		{"subdivision rule", "US-CA", money.Money{Amount: 10000, Currency: "USD"}, money.Money{Amount: 725, Currency: "USD"}},
+		{"regions are case insensitive", " us-ca ", money.Money{Amount: 10000, Currency: "USD"}, money.Money{Amount: 725, Currency: "USD"}},
+		{"rounded half away from zero", "US-CA", money.Money{Amount: 1999, Currency: "USD"}, money.Money{Amount: 145, Currency: "USD"}},
+		{"country rule for a subdivision", "DE-BY", money.Money{Amount: 5000, Currency: "EUR"}, money.Money{Amount: 950, Currency: "EUR"}},
+		{"zero rate", "HK", money.Money{Amount: 5000, Currency: "HKD"}, money.Money{Amount: 0, Currency: "HKD"}},
+		{"subdivision without a rule", "US-TX", money.Money{Amount: 10000, Currency: "USD"}, money.Money{Amount: 0, Currency: "USD"}},
+		{"no region", "", money.Money{Amount: 10000, Currency: "USD"}, money.Money{Amount: 0, Currency: "USD"}},
+		{"taxed in the currency of the order", "US-CA", money.Money{Amount: 1000, Currency: "JPY"}, money.Money{Amount: 73, Currency: "JPY"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pricing.Tax(context.Background(), tt.region, tt.taxable)
+			if err != nil {
+				t.Fatalf("Tax() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Tax() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPricingRulesShipping(t *testing.T) {
+	pricing := loadTestPricingRules(t, testPricingRules)
+	usd := func(amount int64) money.Money { return money.Money{Amount: amount, Currency: "USD"} }
+	tests := []struct {
+		name        string
+		region      string
+		weightGrams uint64
+		want        money.Money
+		wantErr     error
+	}{
+		{"lightest class", "US", 500, usd(500), nil},
+		{"at the limit of a class", "US", 1000, usd(500), nil},
+		{"next class", "US", 1001, usd(950), nil},
+		{"class without a limit", "US", 20000, usd(1900), nil},
+		{"country rule for a subdivision", "US-CA", 500, usd(500), nil},
+		{"subdivision rule", "US-AK", 500, usd(3000), nil},
+		// The subdivision has rules, none of which takes the weight
+		{"subdivision rules do not fall back", "US-AK", 2000, money.Money{}, ErrNoShippingRate},
+		{"any region", "FR", 2000, usd(4000), nil},
+		{"no region", "", 800, usd(1500), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pricing.Shipping(context.Background(), tt.region, tt.weightGrams)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Shipping() error = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Shipping() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPricingRulesShippingWithoutRules(t *testing.T) {
+	pricing := NewPricingRules()
+	got, err := pricing.Shipping(context.Background(), "US", 1000)
+	if err != nil {
+		t.Fatalf("Shipping() error = %v", err)
+	}
+	if want := money.Zero(money.DefaultCurrency); got != want {
+		t.Errorf("Shipping() = %v, want %v", got, want)
+	}
+
+	pricing = loadTestPricingRules(t, `shipping: [{region: US, price: "5.00"}]`)
+	if _, err := pricing.Shipping(context.Background(), "DE", 1000); !errors.Is(err, ErrNoShippingRate) {
+		t.Errorf("Shipping() error = %v, want %v", err, ErrNoShippingRate)
+	}
+}
+
+func TestLoadPricingRulesRejectsBadRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{"negative tax rate", `tax: [{region: US, rate: "-0.1"}]`},
+		{"tax rate that is not a number", `tax: [{region: US, rate: "ten percent"}]`},
+		{"bad shipping price", `shipping: [{region: US, price: "cheap"}]`},
+		{"currency that is not a code", `currency: dollars`},
+		{"not YAML", `tax: [`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "pricing_rules.yaml")
+			if err := os.WriteFile(path, []byte(tt.rules), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := LoadPricingRules(path); err == nil {
+				t.Error("LoadPricingRules() error = nil, want an error")
+			}
+		})
+	}
+}
@@ -3,15 +3,39 @@ package order
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/lib/pq"
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// pqUniqueViolation is the Postgres error code of unique constraint violations
+const pqUniqueViolation = "23505"
+
+var (
+	ErrNotFound      = errs.NotFound("order not found")
+	ErrStatusChanged = errs.FailedPrecondition("order status was changed concurrently")
 )
 
 type Repository interface {
 	Close()
 	Ping() error
 	PutOrder(ctx context.Context, o Order) error
-	GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error)
+	GetOrderByID(ctx context.Context, id string) (*Order, error)
+	// GetOrdersForAccount returns up to limit orders of the account, oldest first,
+	// starting after the order with ID afterID, or from the oldest if it is empty
+	GetOrdersForAccount(ctx context.Context, accountID string, afterID string, limit uint64) ([]Order, error)
+	UpdateOrderStatus(ctx context.Context, id string, from OrderStatus, change StatusChange) error
+	// ReserveIdempotencyKey binds the key of the account to orderID unless an
+	// unexpired binding already exists, and returns the order ID the key is bound to
+	ReserveIdempotencyKey(ctx context.Context, accountID string, key string, orderID string, ttl time.Duration) (string, error)
+	ReleaseIdempotencyKey(ctx context.Context, accountID string, key string) error
+	// GetIdempotencyKey returns the order ID the unexpired key of the account is
+	// bound to, or ErrNotFound
+	GetIdempotencyKey(ctx context.Context, accountID string, key string) (string, error)
+	PutCoupon(ctx context.Context, c Coupon) error
+	GetCoupon(ctx context.Context, code string) (*Coupon, error)
 }
 
 type postgresRepository struct {
@@ -39,7 +63,7 @@ func (r *postgresRepository) Ping() error {
 	return r.db.Ping()
 }
 
-func (r *postgresRepository) PutOrder(ctx context.Context, ord Order) error {
+func (r *postgresRepository) PutOrder(ctx context.Context, ord Order) (err error) {
 	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
@@ -53,24 +77,99 @@ func (r *postgresRepository) PutOrder(ctx context.Context, ord Order) error {
 	}()
 	_, err = tx.ExecContext(
 		ctx,
-		"INSERT INTO orders(id, created_at, account_id, total_price) VALUES ($1, $2, $3, $4)",
+		`INSERT INTO orders(id, created_at, account_id, subtotal_amount, tax_amount, shipping_amount, total_price_amount, currency, region, status, coupon_code)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NULLIF($11, ''))`,
 		ord.ID,
 		ord.CreatedAt,
 		ord.AccountID,
-		ord.TotalPrice,
+		ord.Subtotal.Amount,
+		ord.Tax.Amount,
+		ord.Shipping.Amount,
+		ord.TotalPrice.Amount,
+		ord.TotalPrice.Currency,
+		ord.Region,
+		ord.Status,
+		ord.CouponCode,
 	)
 	if err != nil {
 		return err
 	}
+	if ord.CouponCode != "" {
+		// Redeem the coupon in the same transaction, so it is only used up if the
+		// order is stored and concurrent orders cannot exceed its usage limit
+		var res sql.Result
+		res, err = tx.ExecContext(
+			ctx,
+			`UPDATE coupons SET uses = uses + 1
+    WHERE code = $1 AND (max_uses = 0 OR uses < max_uses) AND (expires_at IS NULL OR expires_at > NOW())`,
+			ord.CouponCode,
+		)
+		if err != nil {
+			return err
+		}
+		var affected int64
+		if affected, err = res.RowsAffected(); err != nil {
+			return err
+		}
+		if affected == 0 {
+			err = ErrCouponUnavailable
+			return err
+		}
+	}
+	if a := ord.ShippingAddress; a != nil {
+		_, err = tx.ExecContext(
+			ctx,
+			`INSERT INTO order_shipping_addresses(order_id, address_id, name, line1, line2, city, subdivision, postal_code, country)
+    VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+			ord.ID,
+			a.AddressID,
+			a.Name,
+			a.Line1,
+			a.Line2,
+			a.City,
+			a.Subdivision,
+			a.PostalCode,
+			a.Country,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	for _, discount := range ord.Discounts {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO order_discounts(order_id, product_id, description, amount) VALUES ($1, $2, $3, $4)",
+			ord.ID,
+			discount.ProductID,
+			discount.Description,
+			discount.Amount.Amount,
+		)
+		if err != nil {
+			return err
+		}
+	}
+	for _, change := range ord.StatusHistory {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO order_status_history(order_id, status, actor, changed_at) VALUES ($1, $2, $3, $4)",
+			ord.ID,
+			change.Status,
+			change.Actor,
+			change.ChangedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
 
-	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("order_products", "order_id", "product_id", "quantity")) // Creating a queue and declaring copy into order_products table
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn("order_products", "order_id", "product_id", "quantity", "name", "description", "price_amount", "catalog_price_amount", "catalog_currency", "exchange_rate", "weight_grams")) // Creating a queue and declaring copy into order_products table
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
 	for _, p := range ord.Products {
-		_, err = stmt.ExecContext(ctx, ord.ID, p.ID, p.Quantity) // Add the data to queue
+		_, err = stmt.ExecContext(ctx, ord.ID, p.ID, p.Quantity, p.Name, p.Description, p.Price.Amount, p.CatalogPrice.Amount, p.CatalogPrice.Currency, p.ExchangeRate, p.WeightGrams) // Add the data to queue
 		if err != nil {
 			return err
 		}
@@ -83,79 +182,478 @@ func (r *postgresRepository) PutOrder(ctx context.Context, ord Order) error {
 	return nil
 }
 
-func (r *postgresRepository) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
-	orders := []Order{}
+func (r *postgresRepository) GetOrderByID(ctx context.Context, id string) (*Order, error) {
 	order := &Order{}
-	lastOrder := &Order{}
-	orderedProduct := &OrderedProduct{}
-	products := []OrderedProduct{}
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT
+      id,
+      created_at,
+      account_id,
+      subtotal_amount,
+      tax_amount,
+      shipping_amount,
+      total_price_amount,
+      currency,
+      region,
+      status,
+      COALESCE(coupon_code, '')
+    FROM orders
+    WHERE id = $1`,
+		id,
+	).Scan(
+		&order.ID,
+		&order.CreatedAt,
+		&order.AccountID,
+		&order.Subtotal.Amount,
+		&order.Tax.Amount,
+		&order.Shipping.Amount,
+		&order.TotalPrice.Amount,
+		&order.TotalPrice.Currency,
+		&order.Region,
+		&order.Status,
+		&order.CouponCode,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	order.Subtotal.Currency = order.TotalPrice.Currency
+	order.Tax.Currency = order.TotalPrice.Currency
+	order.Shipping.Currency = order.TotalPrice.Currency
+
+	products, err := r.orderedProducts(ctx, "WHERE order_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	order.Products = products[order.ID]
+	for i := range order.Products {
+		order.Products[i].Price.Currency = order.TotalPrice.Currency
+	}
+
+	history, err := r.statusHistory(ctx, "WHERE order_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	order.StatusHistory = history[order.ID]
 
+	discounts, err := r.discounts(ctx, "WHERE order_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	order.Discounts = discounts[order.ID]
+	for i := range order.Discounts {
+		order.Discounts[i].Amount.Currency = order.TotalPrice.Currency
+	}
+
+	addresses, err := r.shippingAddresses(ctx, "WHERE order_id = $1", id)
+	if err != nil {
+		return nil, err
+	}
+	order.ShippingAddress = addresses[order.ID]
+	return order, nil
+}
+
+// pageOrders selects the IDs of a page of the orders of an account; $1 is the
+// account ID, $2 the ID of the last order of the previous page and $3 the limit
+const pageOrders = "(SELECT id FROM orders WHERE account_id = $1 AND ($2 = '' OR id > $2) ORDER BY id LIMIT $3)"
+
+func (r *postgresRepository) GetOrdersForAccount(ctx context.Context, accountID string, afterID string, limit uint64) ([]Order, error) {
 	rows, err := r.db.QueryContext(
 		ctx,
 		`SELECT
-      o.id,
-      o.created_at,
-      o.account_id,
-      o.total_price::money::numeric::float8,
-      op.product_id,
-      op.quantity
-    FROM orders o JOIN order_products op ON (o.id = op.order_id)
-    WHERE o.account_id = $1
-    ORDER BY o.id`,
+      id,
+      created_at,
+      account_id,
+      subtotal_amount,
+      tax_amount,
+      shipping_amount,
+      total_price_amount,
+      currency,
+      region,
+      status,
+      COALESCE(coupon_code, '')
+    FROM orders
+    WHERE id IN `+pageOrders+`
+    ORDER BY id`,
 		accountID,
+		afterID,
+		limit,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	// Scan rows into Order structs
+
+	orders := []Order{}
 	for rows.Next() {
+		order := Order{}
 		if err = rows.Scan(
 			&order.ID,
 			&order.CreatedAt,
 			&order.AccountID,
-			&order.TotalPrice,
-			&orderedProduct.ID,
-			&orderedProduct.Quantity,
+			&order.Subtotal.Amount,
+			&order.Tax.Amount,
+			&order.Shipping.Amount,
+			&order.TotalPrice.Amount,
+			&order.TotalPrice.Currency,
+			&order.Region,
+			&order.Status,
+			&order.CouponCode,
+		); err != nil {
+			return nil, err
+		}
+		order.Subtotal.Currency = order.TotalPrice.Currency
+		order.Tax.Currency = order.TotalPrice.Currency
+		order.Shipping.Currency = order.TotalPrice.Currency
+		orders = append(orders, order)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	products, err := r.orderedProducts(
+		ctx,
+		"WHERE order_id IN "+pageOrders,
+		accountID,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	history, err := r.statusHistory(
+		ctx,
+		"WHERE order_id IN "+pageOrders,
+		accountID,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	discounts, err := r.discounts(
+		ctx,
+		"WHERE order_id IN "+pageOrders,
+		accountID,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := r.shippingAddresses(
+		ctx,
+		"WHERE order_id IN "+pageOrders,
+		accountID,
+		afterID,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	for i := range orders {
+		orders[i].Products = products[orders[i].ID]
+		for j := range orders[i].Products {
+			orders[i].Products[j].Price.Currency = orders[i].TotalPrice.Currency
+		}
+		orders[i].StatusHistory = history[orders[i].ID]
+		orders[i].ShippingAddress = addresses[orders[i].ID]
+		orders[i].Discounts = discounts[orders[i].ID]
+		for j := range orders[i].Discounts {
+			orders[i].Discounts[j].Amount.Currency = orders[i].TotalPrice.Currency
+		}
+	}
+
+	return orders, nil
+}
+
+// UpdateOrderStatus moves an order from one status to another and records the change.
+// The update only applies if the order is still in the expected status, so concurrent
+// transitions cannot skip the state machine.
+func (r *postgresRepository) UpdateOrderStatus(ctx context.Context, id string, from OrderStatus, change StatusChange) (err error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			tx.Rollback()
+			return
+		}
+		err = tx.Commit()
+	}()
+
+	res, err := tx.ExecContext(
+		ctx,
+		"UPDATE orders SET status = $1 WHERE id = $2 AND status = $3",
+		change.Status,
+		id,
+		from,
+	)
+	if err != nil {
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrStatusChanged
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO order_status_history(order_id, status, actor, changed_at) VALUES ($1, $2, $3, $4)",
+		id,
+		change.Status,
+		change.Actor,
+		change.ChangedAt,
+	)
+	return err
+}
+
+func (r *postgresRepository) ReserveIdempotencyKey(ctx context.Context, accountID string, key string, orderID string, ttl time.Duration) (string, error) {
+	var reservedID string
+	err := r.db.QueryRowContext(
+		ctx,
+		`INSERT INTO idempotency_keys(account_id, key, order_id, expires_at) VALUES ($1, $2, $3, $4)
+    ON CONFLICT (account_id, key) DO UPDATE SET order_id = EXCLUDED.order_id, expires_at = EXCLUDED.expires_at
+    WHERE idempotency_keys.expires_at < NOW()
+    RETURNING order_id`,
+		accountID,
+		key,
+		orderID,
+		time.Now().Add(ttl),
+	).Scan(&reservedID)
+	if errors.Is(err, sql.ErrNoRows) {
+		// The key is already bound to an unexpired order
+		err = r.db.QueryRowContext(
+			ctx,
+			"SELECT order_id FROM idempotency_keys WHERE account_id = $1 AND key = $2",
+			accountID,
+			key,
+		).Scan(&reservedID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return reservedID, nil
+}
+
+func (r *postgresRepository) ReleaseIdempotencyKey(ctx context.Context, accountID string, key string) error {
+	_, err := r.db.ExecContext(ctx, "DELETE FROM idempotency_keys WHERE account_id = $1 AND key = $2", accountID, key)
+	return err
+}
+
+func (r *postgresRepository) GetIdempotencyKey(ctx context.Context, accountID string, key string) (string, error) {
+	var orderID string
+	err := r.db.QueryRowContext(
+		ctx,
+		"SELECT order_id FROM idempotency_keys WHERE account_id = $1 AND key = $2 AND expires_at >= NOW()",
+		accountID,
+		key,
+	).Scan(&orderID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	return orderID, nil
+}
+
+// orderedProducts loads the products of orders matching the given filter, grouped
+// by order ID. Orders without products have no entry. Prices are in the currency
+// of their order, which callers fill in.
+func (r *postgresRepository) orderedProducts(ctx context.Context, filter string, args ...any) (map[string][]OrderedProduct, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		`SELECT
+      order_id,
+      product_id,
+      quantity,
+      name,
+      description,
+      price_amount,
+      catalog_price_amount,
+      catalog_currency,
+      exchange_rate,
+      weight_grams
+    FROM order_products `+filter,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := map[string][]OrderedProduct{}
+	for rows.Next() {
+		var orderID string
+		p := OrderedProduct{}
+		if err = rows.Scan(
+			&orderID,
+			&p.ID,
+			&p.Quantity,
+			&p.Name,
+			&p.Description,
+			&p.Price.Amount,
+			&p.CatalogPrice.Amount,
+			&p.CatalogPrice.Currency,
+			&p.ExchangeRate,
+			&p.WeightGrams,
 		); err != nil {
 			return nil, err
 		}
-		// Scan order
-		if lastOrder.ID != "" && lastOrder.ID != order.ID {
-			newOrder := Order{
-				ID:         lastOrder.ID,
-				AccountID:  lastOrder.AccountID,
-				CreatedAt:  lastOrder.CreatedAt,
-				TotalPrice: lastOrder.TotalPrice,
-				Products:   products,
-			}
-			orders = append(orders, newOrder)
-			products = []OrderedProduct{}
+		products[orderID] = append(products[orderID], p)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return products, nil
+}
+
+// statusHistory loads status changes matching the given filter, grouped by order ID
+func (r *postgresRepository) statusHistory(ctx context.Context, filter string, args ...any) (map[string][]StatusChange, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT order_id, status, actor, changed_at FROM order_status_history "+filter+" ORDER BY changed_at, id",
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	history := map[string][]StatusChange{}
+	for rows.Next() {
+		var orderID string
+		change := StatusChange{}
+		if err = rows.Scan(&orderID, &change.Status, &change.Actor, &change.ChangedAt); err != nil {
+			return nil, err
 		}
-		// Scan products
-		products = append(products, OrderedProduct{
-			ID:       orderedProduct.ID,
-			Quantity: orderedProduct.Quantity,
-		})
+		history[orderID] = append(history[orderID], change)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
 
-		*lastOrder = *order
+// discounts loads the discounts of orders matching the given filter, grouped by
+// order ID. Amounts are in the currency of their order, which callers fill in.
+func (r *postgresRepository) discounts(ctx context.Context, filter string, args ...any) (map[string][]Discount, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT order_id, product_id, description, amount FROM order_discounts "+filter+" ORDER BY id",
+		args...,
+	)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	// Add last order (or first :D)
-	if lastOrder != nil {
-		newOrder := Order{
-			ID:         lastOrder.ID,
-			AccountID:  lastOrder.AccountID,
-			CreatedAt:  lastOrder.CreatedAt,
-			TotalPrice: lastOrder.TotalPrice,
-			Products:   products,
+	discounts := map[string][]Discount{}
+	for rows.Next() {
+		var orderID string
+		discount := Discount{}
+		if err = rows.Scan(&orderID, &discount.ProductID, &discount.Description, &discount.Amount.Amount); err != nil {
+			return nil, err
 		}
-		orders = append(orders, newOrder)
+		discounts[orderID] = append(discounts[orderID], discount)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return discounts, nil
+}
 
+// shippingAddresses loads the shipping addresses of the orders matching the given
+// filter, by order ID
+func (r *postgresRepository) shippingAddresses(ctx context.Context, filter string, args ...any) (map[string]*ShippingAddress, error) {
+	rows, err := r.db.QueryContext(
+		ctx,
+		"SELECT order_id, address_id, name, line1, line2, city, subdivision, postal_code, country FROM order_shipping_addresses "+filter,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	addresses := map[string]*ShippingAddress{}
+	for rows.Next() {
+		var orderID string
+		a := &ShippingAddress{}
+		if err = rows.Scan(&orderID, &a.AddressID, &a.Name, &a.Line1, &a.Line2, &a.City, &a.Subdivision, &a.PostalCode, &a.Country); err != nil {
+			return nil, err
+		}
+		addresses[orderID] = a
+	}
 	if err = rows.Err(); err != nil {
 		return nil, err
 	}
+	return addresses, nil
+}
 
-	return orders, nil
-}
\ No newline at end of file
+func (r *postgresRepository) PutCoupon(ctx context.Context, c Coupon) error {
+	_, err := r.db.ExecContext(
+		ctx,
+		`INSERT INTO coupons(code, type, percent_off, amount_off, currency, buy_quantity, get_quantity, product_id, max_uses, uses, expires_at)
+    VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8, $9, $10, $11)`,
+		c.Code,
+		c.Type,
+		c.PercentOff,
+		c.AmountOff.Amount,
+		c.AmountOff.Currency,
+		c.BuyQuantity,
+		c.GetQuantity,
+		c.ProductID,
+		c.MaxUses,
+		c.Uses,
+		c.ExpiresAt,
+	)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == pqUniqueViolation {
+		return errs.AlreadyExists("coupon already exists")
+	}
+	return err
+}
+
+func (r *postgresRepository) GetCoupon(ctx context.Context, code string) (*Coupon, error) {
+	c := &Coupon{}
+	var currency sql.NullString
+	var expiresAt sql.NullTime
+	err := r.db.QueryRowContext(
+		ctx,
+		`SELECT code, type, percent_off, amount_off, currency, buy_quantity, get_quantity, product_id, max_uses, uses, expires_at
+    FROM coupons WHERE code = $1`,
+		code,
+	).Scan(
+		&c.Code,
+		&c.Type,
+		&c.PercentOff,
+		&c.AmountOff.Amount,
+		&currency,
+		&c.BuyQuantity,
+		&c.GetQuantity,
+		&c.ProductID,
+		&c.MaxUses,
+		&c.Uses,
+		&expiresAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrCouponNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.AmountOff.Currency = currency.String
+	if expiresAt.Valid {
+		c.ExpiresAt = &expiresAt.Time
+	}
+	return c, nil
+}
@@ -5,9 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"net"
 
 	"github.com/sdshah09/GoCore/account"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
 	"github.com/sdshah09/GoCore/order/pb"
 	"github.com/sdshah09/GoCore/product"
 	"google.golang.org/grpc"
@@ -21,23 +25,25 @@ type grpcServer struct {
 	productClient *product.Client
 }
 
-func ListenGRPC(service Service, accountURL string, productURL string, port int) error {
-	accountClient, err := account.NewClient(accountURL)
-	if err != nil {
-		return err
-	}
-	productClient, err := product.NewClient(productURL)
-	if err != nil {
-		accountClient.Close()
-		return err
-	}
+// policy lets customers place, read and cancel their own orders, checked in the
+// handlers; order fulfilment and coupons are managed by admins
+var policy = auth.Policy{
+	pb.OrderService_PostOrder_FullMethodName:                auth.Customer,
+	pb.OrderService_GetOrder_FullMethodName:                 auth.Customer,
+	pb.OrderService_GetOrderByIdempotencyKey_FullMethodName: auth.Customer,
+	pb.OrderService_GetOrdersForAccount_FullMethodName:      auth.Customer,
+	pb.OrderService_UpdateOrderStatus_FullMethodName:        auth.Admin,
+	pb.OrderService_CancelOrder_FullMethodName:              auth.Customer,
+	pb.OrderService_PostCoupon_FullMethodName:               auth.Admin,
+	pb.OrderService_GetCoupon_FullMethodName:                auth.Customer,
+}
+
+func ListenGRPC(service Service, tokens *auth.TokenManager, accountClient *account.Client, productClient *product.Client, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
-		accountClient.Close()
-		productClient.Close()
 		return err
 	}
-	serv := grpc.NewServer()
+	serv := grpc.NewServer(grpc.ChainUnaryInterceptor(errs.UnaryServerInterceptor, auth.UnaryServerInterceptor(tokens, policy)))
 	pb.RegisterOrderServiceServer(serv, &grpcServer{
 		service:       service,
 		accountClient: accountClient,
@@ -47,10 +53,56 @@ func ListenGRPC(service Service, accountURL string, productURL string, port int)
 }
 
 func (server *grpcServer) PostOrder(ctx context.Context, r *pb.PostOrderRequest) (*pb.PostOrderResponse, error) {
-	_, err := server.accountClient.GetAccount(ctx, r.AccountID)
+	if err := auth.CheckAccount(ctx, r.AccountID); err != nil {
+		return nil, err
+	}
+	// A replay returns the order placed the first time, even if the account,
+	// address or products have changed since
+	if r.IdempotencyKey != "" {
+		o, err := server.service.GetOrderByIdempotencyKey(ctx, r.AccountID, r.IdempotencyKey)
+		if err == nil {
+			return &pb.PostOrderResponse{Order: orderToProto(o)}, nil
+		}
+		if !errors.Is(err, ErrNotFound) {
+			log.Println("Error getting order by idempotency key: ", err)
+			return nil, err
+		}
+	}
+	for _, p := range r.Products {
+		if p.Quantity == 0 || p.Quantity > math.MaxInt32 {
+			return nil, ErrInvalidQuantity
+		}
+	}
+	a, err := server.accountClient.GetAccount(ctx, r.AccountID)
 	if err != nil {
 		log.Println("Error getting account: ", err)
-		return nil, errors.New("account not found")
+		return nil, err
+	}
+	if a.DeletedAt != nil {
+		return nil, account.ErrNotFound
+	}
+
+	var shippingAddress *ShippingAddress
+	if r.AddressId != "" {
+		address, err := server.accountClient.GetAddress(ctx, r.AddressId)
+		if err != nil {
+			log.Println("Error getting address: ", err)
+			return nil, err
+		}
+		// Addresses of other accounts are treated as unknown
+		if address.AccountID != r.AccountID {
+			return nil, account.ErrAddressNotFound
+		}
+		shippingAddress = &ShippingAddress{
+			AddressID:   address.ID,
+			Name:        address.Name,
+			Line1:       address.Line1,
+			Line2:       address.Line2,
+			City:        address.City,
+			Subdivision: address.Subdivision,
+			PostalCode:  address.PostalCode,
+			Country:     address.Country,
+		}
 	}
 
 	// Extract product IDs from request
@@ -62,16 +114,17 @@ func (server *grpcServer) PostOrder(ctx context.Context, r *pb.PostOrderRequest)
 	orderedProducts, err := server.productClient.GetProducts(ctx, "", productIDs, 0, 0)
 	if err != nil {
 		log.Println("Error Getting products: ", err)
-		return nil, errors.New("products not found")
+		return nil, err
 	}
 	products := []OrderedProduct{}
 	for _, p := range orderedProducts {
 		product := OrderedProduct{
-			ID:          p.ID,
-			Quantity:    0,
-			Price:       p.Price,
-			Name:        p.Name,
-			Description: p.Description,
+			ID:           p.ID,
+			Quantity:     0,
+			CatalogPrice: p.Price,
+			Name:         p.Name,
+			Description:  p.Description,
+			WeightGrams:  p.WeightGrams,
 		}
 		for _, rp := range r.Products {
 			if rp.ProductId == p.ID {
@@ -82,84 +135,185 @@ func (server *grpcServer) PostOrder(ctx context.Context, r *pb.PostOrderRequest)
 			products = append(products, product)
 		}
 	}
-	order, err := server.service.PostOrder(ctx, r.AccountID, products)
+	if len(products) == 0 {
+		return nil, errs.InvalidArgument("order must contain at least one existing product")
+	}
+	order, err := server.service.PostOrder(ctx, r.AccountID, a.Currency, r.Region, shippingAddress, products, r.CouponCode, r.IdempotencyKey)
 	if err != nil {
 		log.Println("Error posting order: ", err)
-		return nil, errors.New("could not post order")
-	}
-	orderProto := &pb.Order{
-		Id:         order.ID,
-		AccountId:  order.AccountID,
-		TotalPrice: order.TotalPrice,
-		Products:   []*pb.OrderProduct{},
-	}
-	orderProto.CreatedAt = timestamppb.New(order.CreatedAt)
-	for _, p := range order.Products {
-		orderProto.Products = append(orderProto.Products, &pb.OrderProduct{
-			Id:          p.ID,
-			Name:        p.Name,
-			Price:       p.Price,
-			Description: p.Description,
-			Quantity:    p.Quantity,
-		})
+		return nil, err
 	}
 	return &pb.PostOrderResponse{
-		Order: orderProto,
+		Order: orderToProto(order),
 	}, nil
 }
 
+func (server *grpcServer) GetOrder(ctx context.Context, r *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	o, err := server.ownOrder(ctx, r.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetOrderResponse{Order: orderToProto(o)}, nil
+}
+
+func (server *grpcServer) GetOrderByIdempotencyKey(ctx context.Context, r *pb.GetOrderByIdempotencyKeyRequest) (*pb.GetOrderByIdempotencyKeyResponse, error) {
+	if err := auth.CheckAccount(ctx, r.AccountId); err != nil {
+		return nil, err
+	}
+	o, err := server.service.GetOrderByIdempotencyKey(ctx, r.AccountId, r.IdempotencyKey)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetOrderByIdempotencyKeyResponse{Order: orderToProto(o)}, nil
+}
+
 func (server *grpcServer) GetOrdersForAccount(ctx context.Context, r *pb.GetOrdersForAccountRequest) (*pb.GetOrdersForAccountResponse, error) {
-	accountOrders, err := server.service.GetOrdersForAccount(ctx, r.AccountID)
+	if err := auth.CheckAccount(ctx, r.AccountID); err != nil {
+		return nil, err
+	}
+	accountOrders, hasNextPage, err := server.service.GetOrdersForAccount(ctx, r.AccountID, r.After, r.First)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
-	// Get product IDs from all orders
-	productIDs := []string{}
-	for _, ord := range accountOrders {
-		for _, p := range ord.Products {
-			productIDs = append(productIDs, p.ID)
-		}
+
+	// Product details are stored with each order, so no product service lookup is needed
+	orders := []*pb.Order{}
+	for _, o := range accountOrders {
+		orders = append(orders, orderToProto(&o))
 	}
+	return &pb.GetOrdersForAccountResponse{Orders: orders, HasNextPage: hasNextPage}, nil
+}
 
-	// Get product details
-	products, err := server.productClient.GetProducts(ctx, "", productIDs, 0, 0)
+func (server *grpcServer) UpdateOrderStatus(ctx context.Context, r *pb.UpdateOrderStatusRequest) (*pb.UpdateOrderStatusResponse, error) {
+	o, err := server.service.UpdateOrderStatus(ctx, r.Id, OrderStatus(r.Status), auth.Caller(ctx))
 	if err != nil {
-		log.Println("Error getting products: ", err)
+		log.Println(err)
 		return nil, err
 	}
+	return &pb.UpdateOrderStatusResponse{Order: orderToProto(o)}, nil
+}
 
-	// Create product map for quick lookup
-	productMap := map[string]*product.Product{}
-	for _, p := range products {
-		productMap[p.ID] = &p
+func (server *grpcServer) CancelOrder(ctx context.Context, r *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	if _, err := server.ownOrder(ctx, r.Id); err != nil {
+		return nil, err
 	}
+	o, err := server.service.CancelOrder(ctx, r.Id, auth.Caller(ctx))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.CancelOrderResponse{Order: orderToProto(o)}, nil
+}
 
-	// Convert orders to protobuf format
-	orders := []*pb.Order{}
-	for _, o := range accountOrders {
-		op := &pb.Order{
-			AccountId:  o.AccountID,
-			Id:         o.ID,
-			TotalPrice: o.TotalPrice,
-			Products:   []*pb.OrderProduct{},
-		}
-		op.CreatedAt = timestamppb.New(o.CreatedAt)
-
-		// Add products to order
-		for _, orderProduct := range o.Products {
-			if product, exists := productMap[orderProduct.ID]; exists {
-				op.Products = append(op.Products, &pb.OrderProduct{
-					Id:          orderProduct.ID,
-					Name:        product.Name,
-					Description: product.Description,
-					Price:       product.Price,
-					Quantity:    orderProduct.Quantity,
-				})
-			}
+func (server *grpcServer) PostCoupon(ctx context.Context, r *pb.PostCouponRequest) (*pb.PostCouponResponse, error) {
+	if r.Coupon == nil {
+		return nil, errs.InvalidArgument("coupon is required")
+	}
+	c, err := server.service.PostCoupon(ctx, couponFromProto(r.Coupon))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.PostCouponResponse{Coupon: couponToProto(c)}, nil
+}
+
+func (server *grpcServer) GetCoupon(ctx context.Context, r *pb.GetCouponRequest) (*pb.GetCouponResponse, error) {
+	c, err := server.service.GetCoupon(ctx, r.Code)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.GetCouponResponse{Coupon: couponToProto(c)}, nil
+}
+
+// ownOrder returns the order if the caller may act on the account that placed it
+func (server *grpcServer) ownOrder(ctx context.Context, id string) (*Order, error) {
+	o, err := server.service.GetOrder(ctx, id)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	if err := auth.CheckAccount(ctx, o.AccountID); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// orderToProto converts a domain order into its protobuf representation
+func orderToProto(o *Order) *pb.Order {
+	orderProto := &pb.Order{
+		Id:         o.ID,
+		AccountId:  o.AccountID,
+		Subtotal:   moneyToProto(o.Subtotal),
+		Tax:        moneyToProto(o.Tax),
+		Shipping:   moneyToProto(o.Shipping),
+		TotalPrice: moneyToProto(o.TotalPrice),
+		Region:     o.Region,
+		CreatedAt:  timestamppb.New(o.CreatedAt),
+		Status:     string(o.Status),
+		Products:   []*pb.OrderProduct{},
+		CouponCode: o.CouponCode,
+	}
+	for _, change := range o.StatusHistory {
+		orderProto.StatusHistory = append(orderProto.StatusHistory, &pb.OrderStatusChange{
+			Status:    string(change.Status),
+			ChangedAt: timestamppb.New(change.ChangedAt),
+			Actor:     change.Actor,
+		})
+	}
+	for _, p := range o.Products {
+		orderProto.Products = append(orderProto.Products, &pb.OrderProduct{
+			Id:           p.ID,
+			Name:         p.Name,
+			Description:  p.Description,
+			Price:        moneyToProto(p.Price),
+			CatalogPrice: moneyToProto(p.CatalogPrice),
+			ExchangeRate: p.ExchangeRate,
+			Quantity:     p.Quantity,
+			WeightGrams:  p.WeightGrams,
+		})
+	}
+	if a := o.ShippingAddress; a != nil {
+		orderProto.ShippingAddress = &pb.OrderAddress{
+			AddressId:   a.AddressID,
+			Name:        a.Name,
+			Line1:       a.Line1,
+			Line2:       a.Line2,
+			City:        a.City,
+			Subdivision: a.Subdivision,
+			PostalCode:  a.PostalCode,
+			Country:     a.Country,
 		}
+	}
+	for _, d := range o.Discounts {
+		orderProto.Discounts = append(orderProto.Discounts, &pb.OrderDiscount{
+			ProductId:   d.ProductID,
+			Description: d.Description,
+			Amount:      moneyToProto(d.Amount),
+		})
+	}
+	return orderProto
+}
 
-		orders = append(orders, op)
+func couponToProto(c *Coupon) *pb.Coupon {
+	couponProto := &pb.Coupon{
+		Code:        c.Code,
+		Type:        string(c.Type),
+		PercentOff:  c.PercentOff,
+		AmountOff:   moneyToProto(c.AmountOff),
+		BuyQuantity: c.BuyQuantity,
+		GetQuantity: c.GetQuantity,
+		ProductId:   c.ProductID,
+		MaxUses:     c.MaxUses,
+		Uses:        c.Uses,
 	}
-	return &pb.GetOrdersForAccountResponse{Orders: orders}, nil
+	if c.ExpiresAt != nil {
+		couponProto.ExpiresAt = timestamppb.New(*c.ExpiresAt)
+	}
+	return couponProto
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
 }
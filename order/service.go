@@ -2,59 +2,462 @@ package order
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"math/big"
 	"time"
 
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+	"github.com/sdshah09/GoCore/page"
 	"github.com/segmentio/ksuid"
 )
 
+type OrderStatus string
+
+const (
+	StatusPending   OrderStatus = "pending"
+	StatusPaid      OrderStatus = "paid"
+	StatusShipped   OrderStatus = "shipped"
+	StatusDelivered OrderStatus = "delivered"
+	StatusCancelled OrderStatus = "cancelled"
+	StatusRefunded  OrderStatus = "refunded"
+)
+
+// statusTransitions lists the statuses an order may move to from each status.
+// Cancelled and refunded are terminal.
+var statusTransitions = map[OrderStatus][]OrderStatus{
+	StatusPending:   {StatusPaid, StatusCancelled},
+	StatusPaid:      {StatusShipped, StatusCancelled, StatusRefunded},
+	StatusShipped:   {StatusDelivered},
+	StatusDelivered: {StatusRefunded},
+	StatusCancelled: {},
+	StatusRefunded:  {},
+}
+
+// idempotencyKeyTTL is how long a replayed PostOrder returns the original order
+const idempotencyKeyTTL = 24 * time.Hour
+
+// systemActor is recorded in the status history of changes made without a caller
+const systemActor = "system"
+
+var (
+	ErrInvalidStatus           = errs.InvalidArgument("invalid order status")
+	ErrInvalidStatusTransition = errs.FailedPrecondition("invalid order status transition")
+	// Quantities are taken from stock as an int32 delta
+	ErrInvalidQuantity = errs.InvalidArgument(fmt.Sprintf("quantity must be between 1 and %d", math.MaxInt32))
+	// A replay can arrive while the first request is still placing its order
+	ErrOrderInProgress = errs.Unavailable("order with this idempotency key is still being placed, retry later")
+)
+
+// CanTransition reports whether an order in this status may move to status to
+func (status OrderStatus) CanTransition(to OrderStatus) bool {
+	for _, allowed := range statusTransitions[status] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+func (status OrderStatus) Valid() bool {
+	_, ok := statusTransitions[status]
+	return ok
+}
+
 type Order struct {
-	ID         string
-	CreatedAt  time.Time
-	TotalPrice float64
-	AccountID  string
-	Products   []OrderedProduct
+	ID        string
+	CreatedAt time.Time
+	// Subtotal is the sum of the product prices. TotalPrice is the subtotal minus
+	// Discounts, plus Tax and Shipping.
+	Subtotal   money.Money
+	Tax        money.Money
+	Shipping   money.Money
+	TotalPrice money.Money
+	Region     string
+	// ShippingAddress is nil for orders placed without an address
+	ShippingAddress *ShippingAddress
+	AccountID       string
+	Status          OrderStatus
+	StatusHistory   []StatusChange
+	Products        []OrderedProduct
+	// CouponCode is the coupon redeemed by the order, if any, and Discounts
+	// the breakdown of what it took off TotalPrice
+	CouponCode string
+	Discounts  []Discount
+}
+
+// ShippingAddress is the address an order ships to. It is copied from the
+// account's addresses when the order is placed, so later edits to the address do
+// not change the order.
+type ShippingAddress struct {
+	AddressID   string
+	Name        string
+	Line1       string
+	Line2       string
+	City        string
+	Subdivision string
+	PostalCode  string
+	Country     string
+}
+
+// Region returns the ISO 3166-2 region of the address, e.g. "US-CA"
+func (a *ShippingAddress) Region() string {
+	if a.Subdivision == "" {
+		return a.Country
+	}
+	return a.Country + "-" + a.Subdivision
 }
 
 type OrderedProduct struct {
 	ID          string
 	Name        string
 	Description string
-	Price       float64
-	Quantity    uint32
+	// Price is the unit price in the currency of the order
+	Price money.Money
+	// CatalogPrice is the unit price of the product in its own currency, and
+	// ExchangeRate the rate Price was converted from it with
+	CatalogPrice money.Money
+	ExchangeRate string
+	Quantity     uint32
+	WeightGrams  uint32
+}
+
+type StatusChange struct {
+	Status    OrderStatus
+	ChangedAt time.Time
+	Actor     string
+}
+
+// Inventory reserves and releases product stock, keyed by product ID
+type Inventory interface {
+	ReserveStock(ctx context.Context, items map[string]uint32) error
+	ReleaseStock(ctx context.Context, items map[string]uint32) error
 }
 
 type orderService struct {
 	repository Repository
+	inventory  Inventory
+	rates      ExchangeRateProvider
+	tax        TaxCalculator
+	shipping   ShippingCalculator
 }
 
 type Service interface {
-	PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error)
-	GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error)
+	PostOrder(ctx context.Context, accountID string, currency string, region string, shippingAddress *ShippingAddress, products []OrderedProduct, couponCode string, idempotencyKey string) (*Order, error)
+	GetOrder(ctx context.Context, id string) (*Order, error)
+	GetOrderByIdempotencyKey(ctx context.Context, accountID string, idempotencyKey string) (*Order, error)
+	GetOrdersForAccount(ctx context.Context, accountID string, after string, first uint64) ([]Order, bool, error)
+	UpdateOrderStatus(ctx context.Context, id string, status OrderStatus, actor string) (*Order, error)
+	CancelOrder(ctx context.Context, id string, actor string) (*Order, error)
+	PostCoupon(ctx context.Context, coupon Coupon) (*Coupon, error)
+	GetCoupon(ctx context.Context, code string) (*Coupon, error)
 }
 
-func NewService(repo Repository) Service {
-	return &orderService{repo}
+func NewService(repo Repository, inventory Inventory, rates ExchangeRateProvider, tax TaxCalculator, shipping ShippingCalculator) Service {
+	return &orderService{repo, inventory, rates, tax, shipping}
 }
 
-func (service *orderService) PostOrder(ctx context.Context, accountID string, products []OrderedProduct) (*Order, error) {
-	var totalPrice float64
-	for _, product := range products {
-		totalPrice += product.Price * float64(product.Quantity)
+// PostOrder places an order in currency, shipped to region, or to the region of
+// shippingAddress if it is set. The catalog price of each product is converted
+// with the rate supplied by the exchange rate provider, then the order is priced
+// by the pricing pipeline. A coupon given by couponCode is redeemed together with
+// storing the order.
+func (service *orderService) PostOrder(ctx context.Context, accountID string, currency string, region string, shippingAddress *ShippingAddress, products []OrderedProduct, couponCode string, idempotencyKey string) (*Order, error) {
+	if currency == "" {
+		currency = money.DefaultCurrency
+	}
+	if shippingAddress != nil {
+		region = shippingAddress.Region()
 	}
+	id := ksuid.New().String()
+	// A replay returns the order created the first time before anything is
+	// priced, so it succeeds even if a coupon ran out or a price changed since
+	if idempotencyKey != "" {
+		reservedID, err := service.repository.ReserveIdempotencyKey(ctx, accountID, idempotencyKey, id, idempotencyKeyTTL)
+		if err != nil {
+			return nil, err
+		}
+		if reservedID != id {
+			return service.reservedOrder(ctx, reservedID)
+		}
+	}
+	order, err := service.newOrder(ctx, id, accountID, currency, region, shippingAddress, products, couponCode)
+	if err != nil {
+		if idempotencyKey != "" {
+			service.repository.ReleaseIdempotencyKey(ctx, accountID, idempotencyKey)
+		}
+		return nil, err
+	}
+	items := stockItems(order.Products)
+	if err := service.inventory.ReserveStock(ctx, items); err != nil {
+		if idempotencyKey != "" {
+			service.repository.ReleaseIdempotencyKey(ctx, accountID, idempotencyKey)
+		}
+		return nil, err
+	}
+	err = service.repository.PutOrder(ctx, *order)
+	if err != nil {
+		if releaseErr := service.inventory.ReleaseStock(ctx, items); releaseErr != nil {
+			log.Println("Error releasing stock: ", releaseErr)
+		}
+		if idempotencyKey != "" {
+			service.repository.ReleaseIdempotencyKey(ctx, accountID, idempotencyKey)
+		}
+		return nil, err
+	}
+	return order, err
+}
+
+// newOrder builds and prices a pending order with the given ID
+func (service *orderService) newOrder(ctx context.Context, id string, accountID string, currency string, region string, shippingAddress *ShippingAddress, products []OrderedProduct, couponCode string) (*Order, error) {
+	products, err := service.convertPrices(ctx, currency, products)
+	if err != nil {
+		return nil, err
+	}
+	createdAt := time.Now()
 	order := &Order{
-		ID:         ksuid.New().String(),
-		CreatedAt:  time.Now(),
-		TotalPrice: totalPrice,
-		AccountID:  accountID,
+		ID:              id,
+		CreatedAt:       createdAt,
+		TotalPrice:      money.Zero(currency),
+		Region:          normalizeRegion(region),
+		ShippingAddress: shippingAddress,
+		AccountID:       accountID,
+		Status:          StatusPending,
+		StatusHistory: []StatusChange{{
+			Status:    StatusPending,
+			ChangedAt: createdAt,
+			Actor:     accountID,
+		}},
 		Products:   products,
+		CouponCode: normalizeCouponCode(couponCode),
+	}
+	if err := service.price(ctx, order); err != nil {
+		return nil, err
 	}
-	err := service.repository.PutOrder(ctx, *order)
+	return order, nil
+}
+
+func (service *orderService) GetOrder(ctx context.Context, id string) (*Order, error) {
+	return service.repository.GetOrderByID(ctx, id)
+}
+
+// GetOrderByIdempotencyKey returns the order the idempotency key of the account
+// placed, or ErrNotFound if the key is unused or expired
+func (service *orderService) GetOrderByIdempotencyKey(ctx context.Context, accountID string, idempotencyKey string) (*Order, error) {
+	orderID, err := service.repository.GetIdempotencyKey(ctx, accountID, idempotencyKey)
 	if err != nil {
 		return nil, err
 	}
+	return service.reservedOrder(ctx, orderID)
+}
+
+// reservedOrder returns the order an idempotency key is bound to, or
+// ErrOrderInProgress if it is not stored yet
+func (service *orderService) reservedOrder(ctx context.Context, id string) (*Order, error) {
+	order, err := service.repository.GetOrderByID(ctx, id)
+	if errors.Is(err, ErrNotFound) {
+		return nil, ErrOrderInProgress
+	}
 	return order, err
 }
 
-func (service *orderService) GetOrdersForAccount(ctx context.Context, accountID string) ([]Order, error) {
-	return service.repository.GetOrdersForAccount(ctx, accountID)
+// GetOrdersForAccount returns a page of first orders of the account, oldest
+// first, after the cursor after, and whether there are more orders after it
+func (service *orderService) GetOrdersForAccount(ctx context.Context, accountID string, after string, first uint64) ([]Order, bool, error) {
+	afterID, err := page.ID(after)
+	if err != nil {
+		return nil, false, err
+	}
+	size := page.Size(first)
+	orders, err := service.repository.GetOrdersForAccount(ctx, accountID, afterID, size+1)
+	if err != nil {
+		return nil, false, err
+	}
+	if uint64(len(orders)) > size {
+		return orders[:size], true, nil
+	}
+	return orders, false, nil
+}
+
+func (service *orderService) UpdateOrderStatus(ctx context.Context, id string, status OrderStatus, actor string) (*Order, error) {
+	if !status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+	order, err := service.repository.GetOrderByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !order.Status.CanTransition(status) {
+		return nil, ErrInvalidStatusTransition
+	}
+	if actor == "" {
+		actor = systemActor
+	}
+	err = service.repository.UpdateOrderStatus(ctx, id, order.Status, StatusChange{
+		Status:    status,
+		ChangedAt: time.Now(),
+		Actor:     actor,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status == StatusCancelled {
+		// Cancelled orders give their reserved stock back
+		if err := service.inventory.ReleaseStock(ctx, stockItems(order.Products)); err != nil {
+			log.Println("Error releasing stock: ", err)
+		}
+	}
+	return service.repository.GetOrderByID(ctx, id)
+}
+
+func (service *orderService) CancelOrder(ctx context.Context, id string, actor string) (*Order, error) {
+	return service.UpdateOrderStatus(ctx, id, StatusCancelled, actor)
+}
+
+func (service *orderService) PostCoupon(ctx context.Context, coupon Coupon) (*Coupon, error) {
+	if err := coupon.Validate(); err != nil {
+		return nil, err
+	}
+	coupon.Uses = 0
+	if err := service.repository.PutCoupon(ctx, coupon); err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+func (service *orderService) GetCoupon(ctx context.Context, code string) (*Coupon, error) {
+	return service.repository.GetCoupon(ctx, normalizeCouponCode(code))
+}
+
+// price runs the pricing pipeline of an order whose products are priced in the
+// currency of its TotalPrice: subtotal, coupon discounts, tax on the discounted
+// amount, shipping by total weight, and finally the total.
+func (service *orderService) price(ctx context.Context, order *Order) error {
+	currency := order.TotalPrice.Currency
+	subtotal := money.Zero(currency)
+	weightGrams := uint64(0)
+	for _, product := range order.Products {
+		linePrice, err := product.Price.Multiply(int64(product.Quantity))
+		if err != nil {
+			return err
+		}
+		if subtotal, err = subtotal.Add(linePrice); err != nil {
+			return err
+		}
+		weightGrams += uint64(product.WeightGrams) * uint64(product.Quantity)
+	}
+	order.Subtotal = subtotal
+
+	taxable := subtotal
+	if order.CouponCode != "" {
+		discounts, err := service.applyCoupon(ctx, order.CouponCode, currency, order.Products, order.CreatedAt)
+		if err != nil {
+			return err
+		}
+		for _, discount := range discounts {
+			if taxable, err = taxable.Subtract(discount.Amount); err != nil {
+				return err
+			}
+		}
+		order.Discounts = discounts
+	}
+
+	tax, err := service.tax.Tax(ctx, order.Region, taxable)
+	if err != nil {
+		return err
+	}
+	order.Tax = tax
+
+	shipping, err := service.shipping.Shipping(ctx, order.Region, weightGrams)
+	if err != nil {
+		return err
+	}
+	if shipping.Currency != currency {
+		rate, err := service.rate(ctx, shipping.Currency, currency)
+		if err != nil {
+			return err
+		}
+		if shipping, err = shipping.Convert(currency, rate); err != nil {
+			return err
+		}
+	}
+	order.Shipping = shipping
+
+	total, err := taxable.Add(tax)
+	if err != nil {
+		return err
+	}
+	order.TotalPrice, err = total.Add(shipping)
+	return err
+}
+
+// applyCoupon returns the discounts of the coupon with the given code on products
+// priced in currency. The coupon is only checked here; it is redeemed by PutOrder.
+func (service *orderService) applyCoupon(ctx context.Context, code string, currency string, products []OrderedProduct, now time.Time) ([]Discount, error) {
+	coupon, err := service.repository.GetCoupon(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if !coupon.Redeemable(now) {
+		return nil, ErrCouponUnavailable
+	}
+	amountOff := money.Zero(currency)
+	if coupon.Type == DiscountFixed {
+		rate, err := service.rate(ctx, coupon.AmountOff.Currency, currency)
+		if err != nil {
+			return nil, err
+		}
+		if amountOff, err = coupon.AmountOff.Convert(currency, rate); err != nil {
+			return nil, err
+		}
+	}
+	return coupon.Discounts(products, amountOff)
+}
+
+// rate returns the exchange rate from one currency to another, rounded to
+// money.RateDecimals so a stored rate reproduces the amounts converted with it
+func (service *orderService) rate(ctx context.Context, from string, to string) (*big.Rat, error) {
+	rate, err := service.rates.Rate(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	return money.ParseRate(money.FormatRate(rate))
+}
+
+// convertPrices sets the price of every product to its catalog price converted
+// into currency, and records the rate used
+func (service *orderService) convertPrices(ctx context.Context, currency string, products []OrderedProduct) ([]OrderedProduct, error) {
+	rates := map[string]*big.Rat{}
+	converted := make([]OrderedProduct, 0, len(products))
+	for _, p := range products {
+		from := p.CatalogPrice.Currency
+		rate, ok := rates[from]
+		if !ok {
+			var err error
+			if rate, err = service.rate(ctx, from, currency); err != nil {
+				return nil, err
+			}
+			rates[from] = rate
+		}
+		price, err := p.CatalogPrice.Convert(currency, rate)
+		if err != nil {
+			return nil, err
+		}
+		p.Price = price
+		p.ExchangeRate = money.FormatRate(rate)
+		converted = append(converted, p)
+	}
+	return converted, nil
+}
+
+// stockItems sums ordered quantities per product
+func stockItems(products []OrderedProduct) map[string]uint32 {
+	items := map[string]uint32{}
+	for _, p := range products {
+		items[p.ID] += p.Quantity
+	}
+	return items
 }
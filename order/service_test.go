@@ -0,0 +1,130 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sdshah09/GoCore/money"
+	"github.com/segmentio/ksuid"
+)
+
+func TestOrderStatusCanTransition(t *testing.T) {
+	tests := []struct {
+		from OrderStatus
+		to   OrderStatus
+		want bool
+	}{
+		{StatusPending, StatusPaid, true},
+		{StatusPending, StatusCancelled, true},
+		{StatusPending, StatusShipped, false},
+		{StatusPending, StatusRefunded, false},
+		{StatusPending, StatusPending, false},
+		{StatusPaid, StatusShipped, true},
+		{StatusPaid, StatusCancelled, true},
+		{StatusPaid, StatusRefunded, true},
+		{StatusPaid, StatusDelivered, false},
+		{StatusShipped, StatusDelivered, true},
+		{StatusShipped, StatusCancelled, false},
+		{StatusShipped, StatusRefunded, false},
+		{StatusDelivered, StatusRefunded, true},
+		{StatusDelivered, StatusCancelled, false},
+		{StatusCancelled, StatusPending, false},
+		{StatusCancelled, StatusPaid, false},
+		{StatusRefunded, StatusPaid, false},
+		{OrderStatus("lost"), StatusPaid, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			if got := tt.from.CanTransition(tt.to); got != tt.want {
+				t.Errorf("CanTransition() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// releasingInventory records the stock released
+type releasingInventory struct {
+	released map[string]uint32
+}
+
+func (i *releasingInventory) ReserveStock(ctx context.Context, items map[string]uint32) error {
+	return nil
+}
+
+func (i *releasingInventory) ReleaseStock(ctx context.Context, items map[string]uint32) error {
+	for id, quantity := range items {
+		i.released[id] += quantity
+	}
+	return nil
+}
+
+func TestUpdateOrderStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		from         OrderStatus
+		to           OrderStatus
+		actor        string
+		wantErr      error
+		wantActor    string
+		wantReleased uint32
+	}{
+		{name: "pay", from: StatusPending, to: StatusPaid, actor: "admin", wantActor: "admin"},
+		{name: "ship", from: StatusPaid, to: StatusShipped, actor: "admin", wantActor: "admin"},
+		{name: "change without a caller", from: StatusShipped, to: StatusDelivered, wantActor: systemActor},
+		{name: "cancel releases stock", from: StatusPending, to: StatusCancelled, actor: "admin", wantActor: "admin", wantReleased: 3},
+		{name: "skip a status", from: StatusPending, to: StatusDelivered, wantErr: ErrInvalidStatusTransition},
+		{name: "cancel a shipped order", from: StatusShipped, to: StatusCancelled, wantErr: ErrInvalidStatusTransition},
+		{name: "reopen a cancelled order", from: StatusCancelled, to: StatusPending, wantErr: ErrInvalidStatusTransition},
+		{name: "unknown status", from: StatusPending, to: OrderStatus("lost"), wantErr: ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewMemoryRepository()
+			inventory := &releasingInventory{released: map[string]uint32{}}
+			service := NewService(repo, inventory, nil, nil, nil)
+			order := Order{
+				ID:         ksuid.New().String(),
+				CreatedAt:  time.Now(),
+				AccountID:  ksuid.New().String(),
+				Subtotal:   money.Zero("USD"),
+				TotalPrice: money.Zero("USD"),
+				Status:     tt.from,
+				StatusHistory: []StatusChange{
+					{Status: tt.from, ChangedAt: time.Now(), Actor: "customer"},
+				},
+				Products: []OrderedProduct{{ID: "product", Quantity: 3, Price: money.Zero("USD")}},
+			}
+			if err := repo.PutOrder(ctx, order); err != nil {
+				t.Fatal(err)
+			}
+
+			updated, err := service.UpdateOrderStatus(ctx, order.ID, tt.to, tt.actor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateOrderStatus() error = %v, want %v", err, tt.wantErr)
+			}
+			if got := inventory.released["product"]; got != tt.wantReleased {
+				t.Errorf("released %d units, want %d", got, tt.wantReleased)
+			}
+			if tt.wantErr != nil {
+				stored, err := repo.GetOrderByID(ctx, order.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.Status != tt.from {
+					t.Errorf("stored status = %q, want %q", stored.Status, tt.from)
+				}
+				return
+			}
+			if updated.Status != tt.to {
+				t.Errorf("Status = %q, want %q", updated.Status, tt.to)
+			}
+			last := updated.StatusHistory[len(updated.StatusHistory)-1]
+			if last.Status != tt.to || last.Actor != tt.wantActor {
+				t.Errorf("last status change = %+v, want %q by %q", last, tt.to, tt.wantActor)
+			}
+		})
+	}
+}
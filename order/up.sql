@@ -1,13 +1,101 @@
+CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INT PRIMARY KEY,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- The schema below includes every migration in migrations/
+INSERT INTO schema_migrations (version)
+    SELECT generate_series(1, 8)
+    ON CONFLICT DO NOTHING;
+
+-- Coupons discount orders. A coupon with an empty product_id applies to the whole
+-- order; max_uses = 0 means it can be used any number of times.
+CREATE TABLE IF NOT EXISTS coupons (
+    code VARCHAR(64) PRIMARY KEY,
+    type VARCHAR(16) NOT NULL CHECK (type IN ('percent', 'fixed', 'buy_x_get_y')),
+    percent_off INT NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+    amount_off BIGINT NOT NULL DEFAULT 0,
+    currency CHAR(3),
+    buy_quantity INT NOT NULL DEFAULT 0,
+    get_quantity INT NOT NULL DEFAULT 0,
+    product_id VARCHAR(27) NOT NULL DEFAULT '',
+    max_uses INT NOT NULL DEFAULT 0,
+    uses INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP WITH TIME ZONE
+);
+
+-- Amounts are in minor units of the currency of the order
 CREATE TABLE IF NOT EXISTS orders (
     id CHAR(27) PRIMARY KEY,
     created_at TIMESTAMP WITH TIME ZONE NOT NULL,
     account_id CHAR(27) NOT NULL,
-    total_price MONEY NOT NULL
+    subtotal_amount BIGINT NOT NULL,
+    tax_amount BIGINT NOT NULL DEFAULT 0,
+    shipping_amount BIGINT NOT NULL DEFAULT 0,
+    total_price_amount BIGINT NOT NULL,
+    currency CHAR(3) NOT NULL,
+    region VARCHAR(16) NOT NULL DEFAULT '',
+    status VARCHAR(16) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'paid', 'shipped', 'delivered', 'cancelled', 'refunded')),
+    coupon_code VARCHAR(64) REFERENCES coupons (code)
 );
 
+-- Product details are snapshotted at purchase time so order history does not
+-- change when a product is edited or removed from the catalog. The catalog price
+-- and the rate it was converted with are kept so totals can be reproduced.
 CREATE TABLE IF NOT EXISTS order_products (
     order_id CHAR(27) REFERENCES orders (id) ON DELETE CASCADE,
     product_id CHAR(27),
     quantity INT NOT NULL,
+    name TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    price_amount BIGINT NOT NULL DEFAULT 0,
+    catalog_price_amount BIGINT NOT NULL,
+    catalog_currency CHAR(3) NOT NULL,
+    exchange_rate NUMERIC NOT NULL DEFAULT 1,
+    weight_grams INT NOT NULL DEFAULT 0,
     PRIMARY KEY (product_id, order_id)
 );
+
+CREATE TABLE IF NOT EXISTS order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    status VARCHAR(16) NOT NULL,
+    actor VARCHAR(64) NOT NULL,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_status_history_order_id_idx ON order_status_history (order_id);
+
+CREATE TABLE IF NOT EXISTS idempotency_keys (
+    account_id CHAR(27) NOT NULL,
+    key VARCHAR(255) NOT NULL,
+    order_id CHAR(27) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    PRIMARY KEY (account_id, key)
+);
+
+-- Discount breakdown of each order, in the currency of the order
+CREATE TABLE IF NOT EXISTS order_discounts (
+    id BIGSERIAL PRIMARY KEY,
+    order_id CHAR(27) NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id VARCHAR(27) NOT NULL DEFAULT '',
+    description TEXT NOT NULL,
+    amount BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_discounts_order_id_idx ON order_discounts (order_id);
+
+-- Address each order ships to, copied from the account's addresses when the order
+-- is placed. Orders placed without an address have no row.
+CREATE TABLE IF NOT EXISTS order_shipping_addresses (
+    order_id CHAR(27) PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    address_id CHAR(27) NOT NULL,
+    name VARCHAR(64) NOT NULL,
+    line1 VARCHAR(128) NOT NULL,
+    line2 VARCHAR(128) NOT NULL DEFAULT '',
+    city VARCHAR(64) NOT NULL,
+    subdivision VARCHAR(3) NOT NULL DEFAULT '',
+    postal_code VARCHAR(16) NOT NULL DEFAULT '',
+    country CHAR(2) NOT NULL
+);
@@ -0,0 +1,52 @@
+// Package page implements keyset pagination over KSUIDs. A page is the items
+// after a cursor in ID order; the cursor is the opaque encoding of the ID of the
+// last item of the previous page, so pages stay stable under concurrent inserts
+// and never scan the skipped rows.
+package page
+
+import (
+	"encoding/base64"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// MaxSize bounds the number of items of a page; it is also the size of a page
+// when none is asked for
+const MaxSize = 100
+
+// cursorPrefix versions the cursor format, so it can change without old cursors
+// being mistaken for new ones
+const cursorPrefix = "v1:"
+
+var ErrInvalidCursor = errs.InvalidArgument("invalid cursor")
+
+// Cursor returns the cursor of the item with the given ID
+func Cursor(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(cursorPrefix + id))
+}
+
+// ID returns the ID a cursor was made from, or an empty string for the empty
+// cursor, which selects the first page
+func ID(cursor string) (string, error) {
+	if cursor == "" {
+		return "", nil
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", ErrInvalidCursor
+	}
+	id, ok := strings.CutPrefix(string(decoded), cursorPrefix)
+	if !ok || id == "" {
+		return "", ErrInvalidCursor
+	}
+	return id, nil
+}
+
+// Size returns the number of items to return for first, the size asked for
+func Size(first uint64) uint64 {
+	if first == 0 || first > MaxSize {
+		return MaxSize
+	}
+	return first
+}
@@ -0,0 +1,74 @@
+package page
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	ids := []string{
+		"2ZEjjhPyVmVvUlsRlMHyGqBEDmr",
+		"0ujsszwN8NRY24YaXiTIE2VWDTS",
+		"a",
+		"id with spaces/and:colons",
+	}
+	for _, id := range ids {
+		t.Run(id, func(t *testing.T) {
+			cursor := Cursor(id)
+			got, err := ID(cursor)
+			if err != nil {
+				t.Fatalf("ID(%q) error = %v", cursor, err)
+			}
+			if got != id {
+				t.Errorf("ID(Cursor(%q)) = %q", id, got)
+			}
+		})
+	}
+}
+
+func TestID(t *testing.T) {
+	encode := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+	tests := []struct {
+		name    string
+		cursor  string
+		want    string
+		wantErr error
+	}{
+		{"first page", "", "", nil},
+		{"cursor", encode("v1:2ZEjjhPyVmVvUlsRlMHyGqBEDmr"), "2ZEjjhPyVmVvUlsRlMHyGqBEDmr", nil},
+		{"not base64", "not a cursor!", "", ErrInvalidCursor},
+		{"padded base64", base64.URLEncoding.EncodeToString([]byte("v1:abcd")), "", ErrInvalidCursor},
+		{"raw ID", encode("2ZEjjhPyVmVvUlsRlMHyGqBEDmr"), "", ErrInvalidCursor},
+		{"other version", encode("v2:2ZEjjhPyVmVvUlsRlMHyGqBEDmr"), "", ErrInvalidCursor},
+		{"empty ID", encode("v1:"), "", ErrInvalidCursor},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ID(tt.cursor)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ID(%q) error = %v, want %v", tt.cursor, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ID(%q) = %q, want %q", tt.cursor, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSize(t *testing.T) {
+	tests := []struct {
+		first uint64
+		want  uint64
+	}{
+		{0, MaxSize},
+		{1, 1},
+		{MaxSize, MaxSize},
+		{MaxSize + 1, MaxSize},
+	}
+	for _, tt := range tests {
+		if got := Size(tt.first); got != tt.want {
+			t.Errorf("Size(%d) = %d, want %d", tt.first, got, tt.want)
+		}
+	}
+}
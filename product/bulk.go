@@ -0,0 +1,35 @@
+package product
+
+const (
+	// importBatchSize is the number of imported products written with one bulk
+	// request
+	importBatchSize = 500
+	// exportBatchSize is the number of products read per page of an export
+	exportBatchSize = 500
+	// maxImportErrors bounds the number of errors reported by an import
+	maxImportErrors = 1000
+)
+
+// ImportedProduct is a product of an import. A product that replaces an existing
+// one keeps its stock if KeepStock is set, and otherwise gets Stock; a new product
+// always gets Stock.
+type ImportedProduct struct {
+	Product
+	KeepStock bool
+}
+
+// ImportError reports a product that was not imported. Row is the position of
+// the product in the import, counting from 1.
+type ImportError struct {
+	Row       uint64
+	ProductID string
+	Message   string
+}
+
+// ImportResult counts the products imported and the ones that were not. Errors
+// lists the first maxImportErrors of the latter.
+type ImportResult struct {
+	Imported uint64
+	Failed   uint64
+	Errors   []ImportError
+}
@@ -0,0 +1,104 @@
+package product
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+)
+
+// maxCategories bounds the size of the category tree, which is always read whole
+const maxCategories = 10000
+
+var (
+	ErrCategoryNotFound = errs.NotFound("category not found")
+	ErrCategoryExists   = errs.AlreadyExists("category already exists")
+	ErrCategoryInUse    = errs.FailedPrecondition("category has subcategories or products")
+	ErrInvalidCategory  = errs.InvalidArgument("invalid category")
+)
+
+// Category is a node of the category tree. Products refer to categories by
+// slug, so the slug of a category never changes; its name and parent may.
+type Category struct {
+	Slug string `json:"slug"`
+	Name string `json:"name"`
+	// Parent is the slug of the parent category, or empty for top-level categories
+	Parent string `json:"parent"`
+}
+
+// categoryTree indexes the categories by slug and by parent
+type categoryTree struct {
+	categories map[string]Category
+	children   map[string][]string
+}
+
+func newCategoryTree(categories []Category) *categoryTree {
+	tree := &categoryTree{categories: map[string]Category{}, children: map[string][]string{}}
+	for _, c := range categories {
+		tree.categories[c.Slug] = c
+		tree.children[c.Parent] = append(tree.children[c.Parent], c.Slug)
+	}
+	return tree
+}
+
+// withDescendants returns the slugs and the slugs of all their descendants.
+// Unknown slugs are kept, they match no products.
+func (t *categoryTree) withDescendants(slugs []string) []string {
+	result := []string{}
+	seen := map[string]bool{}
+	var visit func(slug string)
+	visit = func(slug string) {
+		if seen[slug] {
+			return
+		}
+		seen[slug] = true
+		result = append(result, slug)
+		for _, child := range t.children[slug] {
+			visit(child)
+		}
+	}
+	for _, slug := range slugs {
+		visit(slug)
+	}
+	return result
+}
+
+// isDescendant reports whether the category with slug descends from ancestor
+func (t *categoryTree) isDescendant(slug string, ancestor string) bool {
+	// Parents are checked on every change, so the walk ends at a top-level category
+	for slug != "" {
+		if slug == ancestor {
+			return true
+		}
+		slug = t.categories[slug].Parent
+	}
+	return false
+}
+
+// checkExist returns ErrCategoryNotFound if any of the slugs is not a category
+func (t *categoryTree) checkExist(slugs []string) error {
+	for _, slug := range slugs {
+		if _, exists := t.categories[slug]; !exists {
+			return fmt.Errorf("%w: %s", ErrCategoryNotFound, slug)
+		}
+	}
+	return nil
+}
+
+// validateCategory normalizes the slug, name and parent of a category
+func validateCategory(c Category) (Category, error) {
+	slugs, err := normalizeCategories([]string{c.Slug})
+	if err != nil {
+		return Category{}, err
+	}
+	c.Slug = slugs[0]
+	c.Name = strings.TrimSpace(c.Name)
+	if c.Name == "" {
+		return Category{}, fmt.Errorf("%w: name must not be empty", ErrInvalidCategory)
+	}
+	c.Parent = strings.ToLower(strings.TrimSpace(c.Parent))
+	if c.Parent == c.Slug {
+		return Category{}, fmt.Errorf("%w: a category cannot be its own parent", ErrInvalidCategory)
+	}
+	return c, nil
+}
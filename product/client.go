@@ -2,10 +2,19 @@ package product
 
 import (
 	"context"
+	"fmt"
+	"io"
 
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
 	"github.com/sdshah09/GoCore/product/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 )
 
 type Client struct {
@@ -13,8 +22,15 @@ type Client struct {
 	service pb.ProductServiceClient
 }
 
-func NewClient(url string) (*Client, error) {
-	conn, err := grpc.NewClient(url, grpc.WithTransportCredentials(insecure.NewCredentials()))
+// NewClient connects to the product service at url and authenticates every call
+// with a token from tokens
+func NewClient(url string, tokens auth.TokenSource) (*Client, error) {
+	conn, err := grpc.NewClient(
+		url,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(errs.UnaryClientInterceptor, auth.UnaryClientInterceptor(tokens)),
+		grpc.WithChainStreamInterceptor(errs.StreamClientInterceptor, auth.StreamClientInterceptor(tokens)),
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -29,21 +45,20 @@ func (c *Client) Close() {
 	c.conn.Close()
 }
 
-func (client *Client) PostProduct(ctx context.Context, name string, description string, price float64) (*Product, error) {
+func (client *Client) PostProduct(ctx context.Context, name string, description string, price money.Money, stock uint32, weightGrams uint32, categories []string, idempotencyKey string) (*Product, error) {
 	res, err := client.service.PostProduct(ctx, &pb.PostProductRequest{
 		Name: name,
 		Description: description,
-		Price: price,
+		Price: moneyToProto(price),
+		Stock: stock,
+		WeightGrams: weightGrams,
+		Categories: categories,
+		IdempotencyKey: idempotencyKey,
 	},)
 	if err != nil {
 		return nil, err
 	}
-	return &Product{
-		ID: res.Product.Id,
-		Name: res.Product.Name,
-		Description: res.Product.Description,
-		Price: res.Product.Price,
-	}, nil
+	return productFromProto(res.Product), nil
 }
 
 func (client *Client) GetProduct(ctx context.Context, id string) (*Product, error) {
@@ -51,12 +66,7 @@ func (client *Client) GetProduct(ctx context.Context, id string) (*Product, erro
 	if err != nil {
 		return nil, err
 	}
-	return &Product{
-		ID: res.Product.Id,
-		Name: res.Product.Name,
-		Description: res.Product.Description,
-		Price: res.Product.Price,
-	}, nil
+	return productFromProto(res.Product), nil
 }
 
 func (client *Client) GetProducts(ctx context.Context, query string, ids []string, skip uint64, take uint64) ([]Product, error) {
@@ -71,12 +81,291 @@ func (client *Client) GetProducts(ctx context.Context, query string, ids []strin
 	}
 	var products []Product
 	for _, pbProduct := range res.Products {
-		products = append(products, Product{
-			ID: pbProduct.Id,
-			Name: pbProduct.Name,
-			Description: pbProduct.Description,
-			Price: pbProduct.Price,
-		})
+		products = append(products, *productFromProto(pbProduct))
 	}
 	return products, nil
-}
\ No newline at end of file
+}
+
+// SearchProducts returns a page of the products matching filter, with the
+// number of matching products and their facets
+func (client *Client) SearchProducts(ctx context.Context, filter SearchFilter, skip uint64, take uint64) (*SearchResult, error) {
+	req := &pb.GetProductsRequest{
+		Skip:       skip,
+		Take:       take,
+		Query:      filter.Query,
+		Categories: filter.Categories,
+		Sort:       string(filter.Sort),
+	}
+	if filter.MinPrice != nil {
+		req.MinPrice = moneyToProto(*filter.MinPrice)
+	}
+	if filter.MaxPrice != nil {
+		req.MaxPrice = moneyToProto(*filter.MaxPrice)
+	}
+	res, err := client.service.GetProducts(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	result := &SearchResult{Products: []Product{}, Total: res.Total}
+	for _, pbProduct := range res.Products {
+		result.Products = append(result.Products, *productFromProto(pbProduct))
+	}
+	for _, r := range res.GetFacets().GetPriceRanges() {
+		priceRange := PriceRangeFacet{Count: r.Count}
+		if r.From != nil {
+			from := moneyFromProto(r.From)
+			priceRange.From = &from
+		}
+		if r.To != nil {
+			to := moneyFromProto(r.To)
+			priceRange.To = &to
+		}
+		result.Facets.PriceRanges = append(result.Facets.PriceRanges, priceRange)
+	}
+	for _, c := range res.GetFacets().GetCategories() {
+		result.Facets.Categories = append(result.Facets.Categories, CategoryFacet{Category: c.Category, Count: c.Count})
+	}
+	return result, nil
+}
+
+// SuggestProducts returns up to size products whose names complete prefix; a
+// size of 0 returns the default number of suggestions
+func (client *Client) SuggestProducts(ctx context.Context, prefix string, size uint32) ([]Suggestion, error) {
+	res, err := client.service.SuggestProducts(ctx, &pb.SuggestProductsRequest{Prefix: prefix, Size: size})
+	if err != nil {
+		return nil, err
+	}
+	suggestions := []Suggestion{}
+	for _, s := range res.Suggestions {
+		suggestions = append(suggestions, Suggestion{ProductID: s.ProductId, Name: s.Name, Highlight: s.Highlight})
+	}
+	return suggestions, nil
+}
+
+// ImportProducts streams the products returned by next to the service until
+// next returns io.EOF, and returns which were imported. Products without an ID
+// get a new one, products with one replace the product with that ID.
+func (client *Client) ImportProducts(ctx context.Context, next func() (*ImportedProduct, error)) (*ImportResult, error) {
+	stream, err := client.service.ImportProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		err = stream.Send(&pb.ImportProductsRequest{Product: productToProto(&p.Product), KeepStock: p.KeepStock})
+		if err == io.EOF {
+			// The service ended the import, CloseAndRecv returns why
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	res, err := stream.CloseAndRecv()
+	if err != nil {
+		return nil, err
+	}
+	result := &ImportResult{Imported: res.Imported, Failed: res.Failed, Errors: []ImportError{}}
+	for _, e := range res.Errors {
+		result.Errors = append(result.Errors, ImportError{Row: e.Row, ProductID: e.ProductId, Message: e.Message})
+	}
+	return result, nil
+}
+
+// ExportProducts calls each with every product, in no particular order, until
+// it returns an error
+func (client *Client) ExportProducts(ctx context.Context, each func(Product) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	// Stops the export if each fails
+	defer cancel()
+	stream, err := client.service.ExportProducts(ctx, &pb.ExportProductsRequest{})
+	if err != nil {
+		return err
+	}
+	for {
+		res, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := each(*productFromProto(res.Product)); err != nil {
+			return err
+		}
+	}
+}
+
+// UpdateProduct changes the non-nil fields of update and returns the updated product
+func (client *Client) UpdateProduct(ctx context.Context, id string, update ProductUpdate) (*Product, error) {
+	req := &pb.UpdateProductRequest{
+		Id:         id,
+		Product:    &pb.Product{},
+		UpdateMask: &fieldmaskpb.FieldMask{},
+	}
+	if update.Name != nil {
+		req.Product.Name = *update.Name
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "name")
+	}
+	if update.Description != nil {
+		req.Product.Description = *update.Description
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "description")
+	}
+	if update.Price != nil {
+		req.Product.Price = moneyToProto(*update.Price)
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "price")
+	}
+	if update.WeightGrams != nil {
+		req.Product.WeightGrams = *update.WeightGrams
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "weight_grams")
+	}
+	if update.Categories != nil {
+		req.Product.Categories = *update.Categories
+		req.UpdateMask.Paths = append(req.UpdateMask.Paths, "categories")
+	}
+	res, err := client.service.UpdateProduct(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return productFromProto(res.Product), nil
+}
+
+func (client *Client) DeleteProduct(ctx context.Context, id string) error {
+	_, err := client.service.DeleteProduct(ctx, &pb.DeleteProductRequest{Id: id})
+	return err
+}
+
+func (client *Client) SetStock(ctx context.Context, id string, stock uint32) (*Product, error) {
+	res, err := client.service.SetStock(ctx, &pb.SetStockRequest{Id: id, Stock: stock})
+	if err != nil {
+		return nil, err
+	}
+	return productFromProto(res.Product), nil
+}
+
+func (client *Client) AdjustStock(ctx context.Context, id string, delta int32) (*Product, error) {
+	res, err := client.service.AdjustStock(ctx, &pb.AdjustStockRequest{Id: id, Delta: delta})
+	if err != nil {
+		return nil, err
+	}
+	return productFromProto(res.Product), nil
+}
+
+// ReserveStock takes the given quantities (keyed by product ID) out of stock.
+// It returns an *InsufficientStockError listing every short product if the
+// reservation cannot be fulfilled.
+func (client *Client) ReserveStock(ctx context.Context, items map[string]uint32) error {
+	_, err := client.service.ReserveStock(ctx, &pb.ReserveStockRequest{Items: stockItemsToProto(items)})
+	if err != nil {
+		return insufficientStockFromStatus(err)
+	}
+	return nil
+}
+
+func (client *Client) ReleaseStock(ctx context.Context, items map[string]uint32) error {
+	_, err := client.service.ReleaseStock(ctx, &pb.ReleaseStockRequest{Items: stockItemsToProto(items)})
+	return err
+}
+
+func (client *Client) PostCategory(ctx context.Context, slug string, name string, parent string) (*Category, error) {
+	res, err := client.service.PostCategory(ctx, &pb.PostCategoryRequest{
+		Category: &pb.Category{Slug: slug, Name: name, Parent: parent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return categoryFromProto(res.Category), nil
+}
+
+// GetCategories returns every category; Parent links them into a tree
+func (client *Client) GetCategories(ctx context.Context) ([]Category, error) {
+	res, err := client.service.GetCategories(ctx, &pb.GetCategoriesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	categories := []Category{}
+	for _, c := range res.Categories {
+		categories = append(categories, *categoryFromProto(c))
+	}
+	return categories, nil
+}
+
+// UpdateCategory replaces the name and parent of the category with the given slug
+func (client *Client) UpdateCategory(ctx context.Context, slug string, name string, parent string) (*Category, error) {
+	res, err := client.service.UpdateCategory(ctx, &pb.UpdateCategoryRequest{
+		Category: &pb.Category{Slug: slug, Name: name, Parent: parent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return categoryFromProto(res.Category), nil
+}
+
+func (client *Client) DeleteCategory(ctx context.Context, slug string) error {
+	_, err := client.service.DeleteCategory(ctx, &pb.DeleteCategoryRequest{Slug: slug})
+	return err
+}
+
+func productFromProto(p *pb.Product) *Product {
+	return &Product{
+		ID:          p.Id,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       moneyFromProto(p.Price),
+		Stock:       p.Stock,
+		WeightGrams: p.WeightGrams,
+		Categories:  p.Categories,
+	}
+}
+
+func categoryFromProto(c *pb.Category) *Category {
+	return &Category{Slug: c.Slug, Name: c.Name, Parent: c.Parent}
+}
+
+// moneyFromProto converts an amount as sent on the wire; a missing amount is zero
+// without a currency, which the service rejects
+func moneyFromProto(m *pb.Money) money.Money {
+	return money.Money{Amount: m.GetAmount(), Currency: m.GetCurrency()}
+}
+
+func stockItemsToProto(items map[string]uint32) []*pb.StockItem {
+	protoItems := []*pb.StockItem{}
+	for id, quantity := range items {
+		protoItems = append(protoItems, &pb.StockItem{ProductId: id, Quantity: quantity})
+	}
+	return protoItems
+}
+
+// insufficientStockFromStatus rebuilds an *InsufficientStockError from the
+// precondition violations attached by the server; other errors are returned as is
+func insufficientStockFromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.FailedPrecondition {
+		return err
+	}
+	stockErr := &InsufficientStockError{}
+	for _, detail := range st.Details() {
+		failure, ok := detail.(*errdetails.PreconditionFailure)
+		if !ok {
+			continue
+		}
+		for _, v := range failure.Violations {
+			if v.Type != stockViolationType {
+				continue
+			}
+			shortage := StockShortage{ProductID: v.Subject}
+			fmt.Sscanf(v.Description, "requested %d, available %d", &shortage.Requested, &shortage.Available)
+			stockErr.Shortages = append(stockErr.Shortages, shortage)
+		}
+	}
+	if len(stockErr.Shortages) == 0 {
+		return err
+	}
+	return stockErr
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/sdshah09/GoCore/money"
+	"github.com/sdshah09/GoCore/product"
+	"github.com/segmentio/ksuid"
+)
+
+// row is a product as written to CSV and NDJSON files. Prices are decimal
+// amounts in major units, e.g. "19.99", like in the GraphQL API. A row without a
+// stock keeps the stock of the product it replaces.
+type row struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Price       string   `json:"price"`
+	Currency    string   `json:"currency"`
+	Stock       *uint32  `json:"stock,omitempty"`
+	WeightGrams uint32   `json:"weight_grams"`
+	Categories  []string `json:"categories"`
+}
+
+// csvColumns are the columns of CSV files; the categories column separates the
+// slugs with categorySeparator
+var csvColumns = []string{"id", "name", "description", "price", "currency", "stock", "weight_grams", "categories"}
+
+const categorySeparator = "|"
+
+// maxLineSize bounds the length of NDJSON lines
+const maxLineSize = 1 << 20
+
+func newRow(p product.Product) row {
+	stock := p.Stock
+	return row{
+		ID:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       p.Price.Decimal(),
+		Currency:    p.Price.Currency,
+		Stock:       &stock,
+		WeightGrams: p.WeightGrams,
+		Categories:  p.Categories,
+	}
+}
+
+// product converts the row, rejecting the ones the product service would reject
+// for their ID or name, so they are reported before anything is sent
+func (r row) product() (*product.ImportedProduct, error) {
+	if r.ID != "" {
+		if _, err := ksuid.Parse(r.ID); err != nil {
+			return nil, errors.New("id: must be a KSUID")
+		}
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return nil, errors.New("name: must not be empty")
+	}
+	price, err := money.Parse(r.Price, r.Currency)
+	if err != nil {
+		return nil, err
+	}
+	p := &product.ImportedProduct{
+		Product: product.Product{
+			ID:          r.ID,
+			Name:        r.Name,
+			Description: r.Description,
+			Price:       price,
+			WeightGrams: r.WeightGrams,
+			Categories:  r.Categories,
+		},
+		KeepStock: r.Stock == nil,
+	}
+	if r.Stock != nil {
+		p.Stock = *r.Stock
+	}
+	return p, nil
+}
+
+// formatOf returns the format of a file from its extension, or an empty string
+// if the extension is not known
+func formatOf(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return "csv"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	}
+	return ""
+}
+
+// rowReader reads the rows of a file. read returns io.EOF after the last row,
+// and a *rowError for a row that cannot be read, after which reading continues;
+// other errors end the file.
+type rowReader interface {
+	read() (*row, error)
+	// line returns the line of the last row read
+	line() int
+}
+
+type rowError struct {
+	err error
+}
+
+func (e *rowError) Error() string {
+	return e.err.Error()
+}
+
+type rowWriter interface {
+	write(r row) error
+	flush() error
+}
+
+func newRowReader(format string, r io.Reader) (rowReader, error) {
+	switch format {
+	case "csv":
+		return newCSVReader(r)
+	case "ndjson":
+		scanner := bufio.NewScanner(r)
+		scanner.Buffer(nil, maxLineSize)
+		return &ndjsonReader{scanner: scanner}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+func newRowWriter(format string, w io.Writer) (rowWriter, error) {
+	switch format {
+	case "csv":
+		out := csv.NewWriter(w)
+		return &csvWriter{out: out}, out.Write(csvColumns)
+	case "ndjson":
+		return &ndjsonWriter{out: bufio.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q", format)
+}
+
+// csvReader reads CSV files with a header naming the columns, in any order.
+// Missing columns are left empty.
+type csvReader struct {
+	in *csv.Reader
+	// columns maps column names to their index in a record
+	columns  map[string]int
+	lastLine int
+}
+
+func newCSVReader(r io.Reader) (*csvReader, error) {
+	in := csv.NewReader(r)
+	in.FieldsPerRecord = -1
+	header, err := in.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("missing CSV header")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"name", "price", "currency"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("missing CSV column %q", name)
+		}
+	}
+	return &csvReader{in: in, columns: columns, lastLine: 1}, nil
+}
+
+func (r *csvReader) read() (*row, error) {
+	record, err := r.in.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		r.lastLine = parseErr.StartLine
+		return nil, &rowError{err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	r.lastLine, _ = r.in.FieldPos(0)
+	field := func(name string) string {
+		if i, ok := r.columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	var stock *uint32
+	if s := field("stock"); s != "" {
+		n, err := parseUint32(s)
+		if err != nil {
+			return nil, &rowError{fmt.Errorf("stock: %w", err)}
+		}
+		stock = &n
+	}
+	weightGrams, err := parseUint32(field("weight_grams"))
+	if err != nil {
+		return nil, &rowError{fmt.Errorf("weight_grams: %w", err)}
+	}
+	categories := []string{}
+	if c := field("categories"); c != "" {
+		categories = strings.Split(c, categorySeparator)
+	}
+	return &row{
+		ID:          field("id"),
+		Name:        field("name"),
+		Description: field("description"),
+		Price:       field("price"),
+		Currency:    field("currency"),
+		Stock:       stock,
+		WeightGrams: weightGrams,
+		Categories:  categories,
+	}, nil
+}
+
+func (r *csvReader) line() int {
+	return r.lastLine
+}
+
+// parseUint32 parses an optional number, empty meaning 0
+func parseUint32(s string) (uint32, error) {
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	return uint32(n), err
+}
+
+// formatStock formats an optional stock, empty if it is not set
+func formatStock(stock *uint32) string {
+	if stock == nil {
+		return ""
+	}
+	return strconv.FormatUint(uint64(*stock), 10)
+}
+
+type csvWriter struct {
+	out *csv.Writer
+}
+
+func (w *csvWriter) write(r row) error {
+	return w.out.Write([]string{
+		r.ID,
+		r.Name,
+		r.Description,
+		r.Price,
+		r.Currency,
+		formatStock(r.Stock),
+		strconv.FormatUint(uint64(r.WeightGrams), 10),
+		strings.Join(r.Categories, categorySeparator),
+	})
+}
+
+func (w *csvWriter) flush() error {
+	w.out.Flush()
+	return w.out.Error()
+}
+
+// ndjsonReader reads one JSON object per line, skipping blank lines
+type ndjsonReader struct {
+	scanner  *bufio.Scanner
+	lastLine int
+}
+
+func (r *ndjsonReader) read() (*row, error) {
+	for r.scanner.Scan() {
+		r.lastLine++
+		line := strings.TrimSpace(r.scanner.Text())
+		if line == "" {
+			continue
+		}
+		parsed := &row{}
+		if err := json.Unmarshal([]byte(line), parsed); err != nil {
+			return nil, &rowError{err}
+		}
+		return parsed, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return nil, err
+	}
+	return nil, io.EOF
+}
+
+func (r *ndjsonReader) line() int {
+	return r.lastLine
+}
+
+type ndjsonWriter struct {
+	out *bufio.Writer
+}
+
+func (w *ndjsonWriter) write(r row) error {
+	line, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if _, err := w.out.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (w *ndjsonWriter) flush() error {
+	return w.out.Flush()
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// readResult is what reading a row of a file gives: the line it was read from,
+// and either its product or whether it was rejected
+type readResult struct {
+	line      int
+	id        string
+	stock     uint32
+	keepStock bool
+	rejected  bool
+}
+
+// readAll reads every row of a file like the import command, converting the ones
+// that can be read into products
+func readAll(t *testing.T, format string, data string) []readResult {
+	t.Helper()
+	in, err := newRowReader(format, strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("newRowReader() error = %v", err)
+	}
+	results := []readResult{}
+	for {
+		r, err := in.read()
+		if err == io.EOF {
+			return results
+		}
+		var rowErr *rowError
+		if errors.As(err, &rowErr) {
+			results = append(results, readResult{line: in.line(), rejected: true})
+			continue
+		}
+		if err != nil {
+			t.Fatalf("read() error = %v", err)
+		}
+		p, err := r.product()
+		if err != nil {
+			results = append(results, readResult{line: in.line(), id: r.ID, rejected: true})
+			continue
+		}
+		results = append(results, readResult{line: in.line(), id: p.ID, stock: p.Stock, keepStock: p.KeepStock})
+	}
+}
+
+const validID = "2ZEjjhPyVmVvUlsRlMHyGqBEDmr"
+
+func TestReadCSV(t *testing.T) {
+	header := "id,name,price,currency,stock,weight_grams\n"
+	tests := []struct {
+		name string
+		row  string
+		want readResult
+	}{
+		{"new product", ",Phone,9.99,USD,5,100\n", readResult{stock: 5}},
+		{"replaced product", validID + ",Phone,9.99,USD,5,100\n", readResult{id: validID, stock: 5}},
+		{"empty stock keeps the stock", validID + ",Phone,9.99,USD,,100\n", readResult{id: validID, keepStock: true}},
+		{"zero stock", ",Phone,9.99,USD,0,100\n", readResult{}},
+		{"short row", ",Phone,9.99,USD\n", readResult{keepStock: true}},
+		{"negative stock", ",Phone,9.99,USD,-1,100\n", readResult{rejected: true}},
+		{"stock that is not a number", ",Phone,9.99,USD,many,100\n", readResult{rejected: true}},
+		{"stock over MaxUint32", ",Phone,9.99,USD,4294967296,100\n", readResult{rejected: true}},
+		{"bad weight", ",Phone,9.99,USD,1,heavy\n", readResult{rejected: true}},
+		{"bad price", ",Phone,cheap,USD,1,100\n", readResult{rejected: true}},
+		{"too many decimals", ",Phone,9.999,USD,1,100\n", readResult{rejected: true}},
+		{"bad currency", ",Phone,9.99,dollars,1,100\n", readResult{rejected: true}},
+		{"empty name", ",  ,9.99,USD,1,100\n", readResult{rejected: true}},
+		{"ID that is not a KSUID", "phone-1,Phone,9.99,USD,1,100\n", readResult{id: "phone-1", rejected: true}},
+		{"bare quote", ",Ph\"one,9.99,USD,1,100\n", readResult{rejected: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := readAll(t, "csv", header+tt.row)
+			tt.want.line = 2
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("read %+v, want [%+v]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadCSVContinuesAfterBadRows(t *testing.T) {
+	data := "name,price,currency,categories\n" +
+		"Phone,9.99,USD,phones|apple\n" +
+		"Case,free,USD,\n" +
+		"\"Cable\nUSB-C\",4.99,USD,\n" +
+		"Charger,19.99,USD,\n"
+	got := readAll(t, "csv", data)
+	want := []readResult{
+		{line: 2, keepStock: true},
+		{line: 3, rejected: true},
+		{line: 4, keepStock: true},
+		{line: 6, keepStock: true},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("read %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("row %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestNewCSVReaderRejectsBadHeaders(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty file", ""},
+		{"missing name", "price,currency\n9.99,USD\n"},
+		{"missing price", "name,currency\nPhone,USD\n"},
+		{"missing currency", "name,price\nPhone,9.99\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newRowReader("csv", strings.NewReader(tt.data)); err == nil {
+				t.Error("newRowReader() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestReadNDJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		line string
+		want readResult
+	}{
+		{"new product", `{"name": "Phone", "price": "9.99", "currency": "USD", "stock": 5}`, readResult{stock: 5}},
+		{"missing stock keeps the stock", `{"id": "` + validID + `", "name": "Phone", "price": "9.99", "currency": "USD"}`, readResult{id: validID, keepStock: true}},
+		{"zero stock", `{"name": "Phone", "price": "9.99", "currency": "USD", "stock": 0}`, readResult{}},
+		{"negative stock", `{"name": "Phone", "price": "9.99", "currency": "USD", "stock": -1}`, readResult{rejected: true}},
+		{"price that is a number", `{"name": "Phone", "price": 9.99, "currency": "USD"}`, readResult{rejected: true}},
+		{"bad price", `{"name": "Phone", "price": "cheap", "currency": "USD"}`, readResult{rejected: true}},
+		{"empty name", `{"name": "", "price": "9.99", "currency": "USD"}`, readResult{rejected: true}},
+		{"ID that is not a KSUID", `{"id": "phone-1", "name": "Phone", "price": "9.99", "currency": "USD"}`, readResult{id: "phone-1", rejected: true}},
+		{"not JSON", `name: Phone`, readResult{rejected: true}},
+		{"truncated", `{"name": "Phone", "price": "9.99"`, readResult{rejected: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Blank lines are skipped but counted
+			got := readAll(t, "ndjson", "\n"+tt.line+"\n")
+			tt.want.line = 2
+			if len(got) != 1 || got[0] != tt.want {
+				t.Errorf("read %+v, want [%+v]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRowRoundTrip(t *testing.T) {
+	stock := uint32(5)
+	rows := []row{
+		{ID: validID, Name: "Phone", Description: "A phone, \"new\"", Price: "9.99", Currency: "USD", Stock: &stock, WeightGrams: 100, Categories: []string{"phones", "apple"}},
+		{ID: validID, Name: "Case", Price: "1.00", Currency: "EUR", Categories: []string{}},
+	}
+	for _, format := range []string{"csv", "ndjson"} {
+		t.Run(format, func(t *testing.T) {
+			var out strings.Builder
+			w, err := newRowWriter(format, &out)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, r := range rows {
+				if err := w.write(r); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.flush(); err != nil {
+				t.Fatal(err)
+			}
+
+			in, err := newRowReader(format, strings.NewReader(out.String()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range rows {
+				got, err := in.read()
+				if err != nil {
+					t.Fatalf("read() error = %v", err)
+				}
+				if got.ID != want.ID || got.Name != want.Name || got.Description != want.Description ||
+					got.Price != want.Price || got.Currency != want.Currency || got.WeightGrams != want.WeightGrams ||
+					strings.Join(got.Categories, "|") != strings.Join(want.Categories, "|") ||
+					formatStock(got.Stock) != formatStock(want.Stock) {
+					t.Errorf("read %+v, want %+v", got, want)
+				}
+			}
+			if _, err := in.read(); err != io.EOF {
+				t.Errorf("read() error = %v, want io.EOF", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// Command catalog imports products into the product service from CSV or NDJSON
+// files, and exports them to such files:
+//
+//	catalog import products.csv
+//	catalog import -format ndjson - < products.ndjson
+//	catalog export products.csv
+//
+// The format is taken from the file extension unless -format is given; exports
+// to standard output are NDJSON by default. It calls the product service at
+// PRODUCT_SERVICE_URL with the admin access token in ACCESS_TOKEN.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/product"
+)
+
+type Config struct {
+	ProductURL  string `envconfig:"PRODUCT_SERVICE_URL" default:"localhost:8082"`
+	AccessToken string `envconfig:"ACCESS_TOKEN" required:"true"`
+}
+
+func main() {
+	log.SetFlags(0)
+	if len(os.Args) < 2 {
+		usage()
+	}
+	command := os.Args[1]
+	flags := flag.NewFlagSet(command, flag.ExitOnError)
+	format := flags.String("format", "", `file format, "csv" or "ndjson"`)
+	flags.Parse(os.Args[2:])
+
+	var cfg Config
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	client, err := product.NewClient(cfg.ProductURL, func(ctx context.Context) (string, error) {
+		return cfg.AccessToken, nil
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer client.Close()
+
+	ctx := context.Background()
+	switch command {
+	case "import":
+		if flags.NArg() != 1 {
+			usage()
+		}
+		err = importProducts(ctx, client, flags.Arg(0), *format)
+	case "export":
+		if flags.NArg() > 1 {
+			usage()
+		}
+		err = exportProducts(ctx, client, flags.Arg(0), *format)
+	default:
+		usage()
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func usage() {
+	log.Fatal("usage: catalog import [-format csv|ndjson] FILE\n       catalog export [-format csv|ndjson] [FILE]")
+}
+
+// importProducts imports the products of a file, or of standard input if path
+// is "-", and reports the rows that were not imported by line. It fails if any row was not imported; the others
+// are imported regardless.
+func importProducts(ctx context.Context, client *product.Client, path string, format string) error {
+	if format == "" {
+		format = formatOf(path)
+	}
+	if format == "" {
+		return fmt.Errorf("unknown format of %s, set -format", path)
+	}
+	var file io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+	in, err := newRowReader(format, file)
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	report := func(line int, productID string, message string) {
+		failed++
+		if productID != "" {
+			message = fmt.Sprintf("product %s: %s", productID, message)
+		}
+		log.Printf("%s:%d: %s", path, line, message)
+	}
+	// Line of each product sent, to report the errors of the service by line
+	lines := []int{}
+	next := func() (*product.ImportedProduct, error) {
+		for {
+			r, err := in.read()
+			var rowErr *rowError
+			if errors.As(err, &rowErr) {
+				report(in.line(), "", err.Error())
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			p, err := r.product()
+			if err != nil {
+				report(in.line(), r.ID, err.Error())
+				continue
+			}
+			lines = append(lines, in.line())
+			return p, nil
+		}
+	}
+	result, err := client.ImportProducts(ctx, next)
+	if err != nil {
+		return err
+	}
+	for _, e := range result.Errors {
+		report(lines[e.Row-1], e.ProductID, e.Message)
+	}
+	// Only the first errors of the service are listed
+	failed += int(result.Failed) - len(result.Errors)
+	log.Printf("Imported %d products", result.Imported)
+	if failed > 0 {
+		return fmt.Errorf("%d products were not imported", failed)
+	}
+	return nil
+}
+
+// exportProducts writes every product to a file, or to standard output if path
+// is empty or "-"
+func exportProducts(ctx context.Context, client *product.Client, path string, format string) error {
+	var file io.Writer = os.Stdout
+	if path != "" && path != "-" {
+		if format == "" {
+			format = formatOf(path)
+		}
+		f, err := os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		file = f
+	}
+	if format == "" {
+		format = "ndjson"
+	}
+	out, err := newRowWriter(format, file)
+	if err != nil {
+		return err
+	}
+	exported := 0
+	err = client.ExportProducts(ctx, func(p product.Product) error {
+		exported++
+		return out.write(newRow(p))
+	})
+	if err != nil {
+		return err
+	}
+	if err := out.flush(); err != nil {
+		return err
+	}
+	log.Printf("Exported %d products", exported)
+	return nil
+}
@@ -2,17 +2,24 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/auth"
 	"github.com/sdshah09/GoCore/product"
 	"github.com/tinrab/retry"
 )
 
 type Config struct {
 	DatabaseURL string `envconfig:"DATABASE_URL"`
+	HealthPort  int    `envconfig:"HEALTH_PORT" default:"8080"`
+	// Repository selects the storage backend: "elasticsearch" (default) or "memory"
+	Repository string `envconfig:"REPOSITORY" default:"elasticsearch"`
+	// JWTSecret verifies the access tokens of callers
+	JWTSecret string `envconfig:"JWT_SECRET" required:"true"`
 }
 
 func main() {
@@ -22,14 +29,25 @@ func main() {
 		log.Fatal(err)
 	}
 
+	// Only access tokens are verified here, so the TTLs are not used
+	tokens, err := auth.NewTokenManager([]byte(cfg.JWTSecret), 0, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	var repo product.Repository
-	retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
-		repo, err = product.NewElasticRepository(cfg.DatabaseURL)
-		if err != nil {
-			log.Println(err)
-		}
-		return
-	})
+	if cfg.Repository == "memory" {
+		log.Println("Using in-memory repository, data is lost on restart")
+		repo = product.NewMemoryRepository()
+	} else {
+		retry.ForeverSleep(2*time.Second, func(_ int) (err error) {
+			repo, err = product.NewElasticRepository(cfg.DatabaseURL)
+			if err != nil {
+				log.Println(err)
+			}
+			return
+		})
+	}
 	defer repo.Close()
 
 	// Start HTTP health check server
@@ -50,12 +68,12 @@ func main() {
 			w.WriteHeader(http.StatusOK)
 			w.Write([]byte("OK"))
 		})
-		log.Println("Health check server listening on :8080")
-		log.Fatal(http.ListenAndServe(":8080", nil))
+		log.Printf("Health check server listening on :%d", cfg.HealthPort)
+		log.Fatal(http.ListenAndServe(fmt.Sprintf(":%d", cfg.HealthPort), nil))
 	}()
 
 	log.Println("Listening on Port 8082...")
 	service := product.NewService(repo)
-	log.Fatal(product.ListenGRPC(service, 8082))
+	log.Fatal(product.ListenGRPC(service, tokens, 8082))
 
 }
@@ -0,0 +1,30 @@
+// Command reindex rebuilds the products index with the current mapping and
+// swaps it in. Run it after changing the mapping, and once to migrate a products
+// index from before the alias; the catalog stays readable and writable.
+package main
+
+import (
+	"context"
+	"log"
+
+	"github.com/kelseyhightower/envconfig"
+	"github.com/sdshah09/GoCore/product"
+)
+
+type Config struct {
+	DatabaseURL string `envconfig:"DATABASE_URL"`
+}
+
+func main() {
+	var cfg Config
+	err := envconfig.Process("", &cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	index, err := product.ReindexProducts(context.Background(), cfg.DatabaseURL)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Products are now read and written through", index)
+}
@@ -0,0 +1,303 @@
+package product
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+
+	"github.com/olivere/elastic/v7"
+)
+
+// productsAlias is the name products are read and written through. It points at
+// one versioned index, products_v1, products_v2, ..., so the index can be
+// rebuilt with a new mapping and swapped in while the catalog stays readable.
+const productsAlias = "products"
+
+// productsIndexVersion matches the names of the versioned products indexes
+var productsIndexVersion = regexp.MustCompile(`^products_v(\d+)$`)
+
+func productsIndexName(version int) string {
+	return fmt.Sprintf("products_v%d", version)
+}
+
+// productsIndex is the body of PUT /products_vN. Fields that are not mapped are
+// kept in the source but not indexed, instead of mapped to whatever type the
+// first value looks like.
+var productsIndex = map[string]interface{}{
+	"settings": map[string]interface{}{
+		"analysis": map[string]interface{}{
+			"analyzer": map[string]interface{}{
+				// Matches "Café" with "cafe" and "CAFE"
+				"product_text": map[string]interface{}{
+					"type":      "custom",
+					"tokenizer": "standard",
+					"filter":    []string{"lowercase", "asciifolding"},
+				},
+			},
+		},
+	},
+	"mappings": map[string]interface{}{
+		"dynamic": false,
+		"properties": map[string]interface{}{
+			"name": map[string]interface{}{
+				"type":     "text",
+				"analyzer": "product_text",
+				"fields": map[string]interface{}{
+					"keyword": map[string]interface{}{"type": "keyword", "ignore_above": 256},
+					"suggest": map[string]interface{}{"type": "search_as_you_type", "analyzer": "product_text"},
+				},
+			},
+			"description":    map[string]interface{}{"type": "text", "analyzer": "product_text"},
+			"price_amount":   map[string]interface{}{"type": "long"},
+			"price_currency": map[string]interface{}{"type": "keyword"},
+			// The float price of documents written before prices were stored in
+			// minor units
+			"price":        map[string]interface{}{"type": "double"},
+			"stock":        map[string]interface{}{"type": "long"},
+			"weight_grams": map[string]interface{}{"type": "long"},
+			"categories":   map[string]interface{}{"type": "keyword"},
+			"created_at":   map[string]interface{}{"type": "date"},
+		},
+	},
+}
+
+// ensureProductsIndex creates the first versioned products index and its alias.
+// A products index created before the alias, with a dynamic mapping, is served
+// as it is; the reindex command migrates it, so that replicas starting together
+// do not all copy it at once.
+func ensureProductsIndex(ctx context.Context, client *elastic.Client) error {
+	current, err := currentProductsIndex(ctx, client)
+	if err != nil || current != "" {
+		return err
+	}
+	exists, err := client.IndexExists(productsAlias).Do(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		log.Println("The products index predates the products alias; run the reindex command to migrate it")
+		return nil
+	}
+
+	_, err = client.CreateIndex(productsIndexName(1)).BodyJson(withAlias(productsIndex)).Do(ctx)
+	if elastic.IsStatusCode(err, 400) {
+		// Another replica created it first
+		current, err = currentProductsIndex(ctx, client)
+		if err == nil && current == "" {
+			err = errors.New("products index exists without the products alias")
+		}
+	}
+	return err
+}
+
+// withAlias returns the body of PUT /products_vN that also points the products
+// alias at the new index
+func withAlias(body map[string]interface{}) map[string]interface{} {
+	withAlias := map[string]interface{}{
+		"aliases": map[string]interface{}{productsAlias: map[string]interface{}{}},
+	}
+	for key, value := range body {
+		withAlias[key] = value
+	}
+	return withAlias
+}
+
+// currentProductsIndex returns the index the products alias points at, or an
+// empty string if there is no alias
+func currentProductsIndex(ctx context.Context, client *elastic.Client) (string, error) {
+	res, err := client.Aliases().Alias(productsAlias).Do(ctx)
+	if elastic.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	indices := res.IndicesByAlias(productsAlias)
+	if len(indices) != 1 {
+		return "", fmt.Errorf("products alias points at %d indexes", len(indices))
+	}
+	return indices[0], nil
+}
+
+// ReindexProducts copies the products into a new versioned index with the
+// current mapping, and atomically points the products alias at it. Products can
+// be read and written throughout: writes made during the copy are caught up
+// before and after the swap. The previous index is kept, read-only, and can be
+// deleted once the new one is checked; only a products index that predates the
+// alias is deleted with the swap.
+func ReindexProducts(ctx context.Context, url string) (string, error) {
+	client, err := elastic.NewClient(
+		elastic.SetURL(url),
+		elastic.SetSniff(false),
+	)
+	if err != nil {
+		return "", err
+	}
+	return reindexProducts(ctx, client)
+}
+
+// maxCatchUpPasses bounds the passes that copy the products written during the
+// previous one before the alias is swapped
+const maxCatchUpPasses = 5
+
+// reindexProducts creates the next versioned index and copies the products into
+// it. On failure before the swap the new index is deleted.
+func reindexProducts(ctx context.Context, client *elastic.Client) (string, error) {
+	current, err := currentProductsIndex(ctx, client)
+	if err != nil {
+		return "", err
+	}
+	// Without the alias, products is the index created with a dynamic mapping
+	legacy := current == ""
+	if legacy {
+		current = productsAlias
+	}
+	versions, err := client.IndexGet("products_v*").Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	// Versions are never reused, so a copy left by a failed reindex is skipped
+	next := 1
+	for name := range versions {
+		if match := productsIndexVersion.FindStringSubmatch(name); match != nil {
+			if version, _ := strconv.Atoi(match[1]); version >= next {
+				next = version + 1
+			}
+		}
+	}
+	index := productsIndexName(next)
+	if _, err = client.CreateIndex(index).BodyJson(productsIndex).Do(ctx); err != nil {
+		return "", err
+	}
+
+	copied, err := catchUp(ctx, client, current, index)
+	if err == nil {
+		log.Printf("Copied %d products from %s into %s", copied, current, index)
+		for pass := 0; pass < maxCatchUpPasses && copied > 0; pass++ {
+			if copied, err = catchUp(ctx, client, current, index); err != nil {
+				break
+			}
+			log.Printf("Caught up %d products written during the copy", copied)
+		}
+	}
+	if err == nil {
+		err = removeDeleted(ctx, client, current, index)
+	}
+	if err == nil {
+		err = swapAlias(ctx, client, current, index, legacy)
+	}
+	if err != nil {
+		// The context may be what failed; clean up regardless
+		if _, deleteErr := client.DeleteIndex(index).Do(context.WithoutCancel(ctx)); deleteErr != nil {
+			log.Println(deleteErr)
+		}
+		return "", err
+	}
+	if legacy {
+		return index, nil
+	}
+
+	// Writes routed to the previous index just before the swap. A product
+	// deleted since the swap is not copied back, as its deletion is newer.
+	if copied, err = catchUp(ctx, client, current, index); err != nil {
+		return "", err
+	}
+	log.Printf("Caught up %d products written during the swap", copied)
+	if err = blockWrites(ctx, client, current); err != nil {
+		return "", err
+	}
+	return index, nil
+}
+
+// catchUp copies the products of index from that are missing from index to or
+// newer there, and returns how many it copied. Copies keep the version of their
+// source, so a product written in both since is not overwritten.
+func catchUp(ctx context.Context, client *elastic.Client, from string, to string) (int64, error) {
+	res, err := client.Reindex().
+		Source(elastic.NewReindexSource().Index(from)).
+		Destination(elastic.NewReindexDestination().Index(to).VersionType("external")).
+		Conflicts("proceed").
+		Refresh("true").
+		WaitForCompletion(true).
+		Do(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(res.Failures) > 0 {
+		return 0, fmt.Errorf("%d products failed to reindex", len(res.Failures))
+	}
+	return res.Created + res.Updated, nil
+}
+
+// removeDeleted deletes the products of index to that were deleted from index
+// from during the copy
+func removeDeleted(ctx context.Context, client *elastic.Client, from string, to string) error {
+	scroll := client.Scroll(to).
+		Sort("_doc", true).
+		FetchSource(false).
+		Size(exportBatchSize).
+		KeepAlive("1m")
+	defer scroll.Clear(context.WithoutCancel(ctx))
+	for {
+		res, err := scroll.Do(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		ids := []string{}
+		for _, hit := range res.Hits.Hits {
+			ids = append(ids, hit.Id)
+		}
+		existing, err := client.Search(from).
+			Query(elastic.NewIdsQuery().Ids(ids...)).
+			FetchSource(false).
+			Size(len(ids)).
+			Do(ctx)
+		if err != nil {
+			return err
+		}
+		found := map[string]bool{}
+		for _, hit := range existing.Hits.Hits {
+			found[hit.Id] = true
+		}
+		bulk := client.Bulk().Index(to).Refresh("true")
+		for _, id := range ids {
+			if !found[id] {
+				bulk.Add(elastic.NewBulkDeleteRequest().Id(id))
+			}
+		}
+		if bulk.NumberOfActions() == 0 {
+			continue
+		}
+		if _, err := bulk.Do(ctx); err != nil {
+			return err
+		}
+	}
+}
+
+// swapAlias points the products alias at index to instead of index from
+func swapAlias(ctx context.Context, client *elastic.Client, from string, to string, legacy bool) error {
+	var remove elastic.AliasAction = elastic.NewAliasRemoveAction(productsAlias).Index(from)
+	if legacy {
+		// An alias cannot have the name of an index, so the old index goes
+		remove = elastic.NewAliasRemoveIndexAction(from)
+	}
+	_, err := client.Alias().
+		Action(elastic.NewAliasAddAction(productsAlias).Index(to), remove).
+		Do(ctx)
+	return err
+}
+
+// blockWrites makes an index read-only
+func blockWrites(ctx context.Context, client *elastic.Client, index string) error {
+	_, err := client.IndexPutSettings(index).
+		BodyJson(map[string]interface{}{"index.blocks.write": true}).
+		Do(ctx)
+	return err
+}
@@ -0,0 +1,377 @@
+package product
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sdshah09/GoCore/money"
+)
+
+// memoryRepository keeps products in process memory. It is meant for tests and
+// local demos; nothing survives a restart.
+type memoryRepository struct {
+	mu              sync.RWMutex
+	products        map[string]Product
+	idempotencyKeys map[string]idempotencyKeyDocument
+	categories      map[string]Category
+}
+
+func NewMemoryRepository() Repository {
+	return &memoryRepository{
+		products:        map[string]Product{},
+		idempotencyKeys: map[string]idempotencyKeyDocument{},
+		categories:      map[string]Category{},
+	}
+}
+
+func (r *memoryRepository) Close() {
+}
+
+func (r *memoryRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
+func (r *memoryRepository) PutProduct(ctx context.Context, product Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.products[product.ID] = product
+	return nil
+}
+
+func (r *memoryRepository) PutProducts(ctx context.Context, products []ImportedProduct) ([]error, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, product := range products {
+		if existing, exists := r.products[product.ID]; exists && product.KeepStock {
+			product.Stock = existing.Stock
+		}
+		r.products[product.ID] = product.Product
+	}
+	return make([]error, len(products)), nil
+}
+
+func (r *memoryRepository) GetProductByID(ctx context.Context, id string) (*Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	product, exists := r.products[id]
+	if !exists {
+		return nil, ErrNotFound
+	}
+	return &product, nil
+}
+
+func (r *memoryRepository) ListAllProducts(ctx context.Context, skip uint64, take uint64) ([]Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	products := []Product{}
+	for _, p := range r.products {
+		products = append(products, p)
+	}
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+	return page(products, skip, take), nil
+}
+
+// ListProductsWithIDs skips unknown IDs, like the Elasticsearch multi get
+func (r *memoryRepository) ListProductsWithIDs(ctx context.Context, ids []string) ([]Product, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	products := []Product{}
+	for _, id := range ids {
+		if p, exists := r.products[id]; exists {
+			products = append(products, p)
+		}
+	}
+	return products, nil
+}
+
+// ScanProducts calls each with every product in ID order. The products are
+// copied first, so each may call back into the repository.
+func (r *memoryRepository) ScanProducts(ctx context.Context, each func(Product) error) error {
+	r.mu.RLock()
+	products := []Product{}
+	for _, p := range r.products {
+		products = append(products, p)
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(products, func(i, j int) bool {
+		return products[i].ID < products[j].ID
+	})
+	for _, p := range products {
+		if err := each(p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SearchProducts ranks products by how many query terms appear in their name or
+// description, allowing for typos like the AUTO fuzziness of Elasticsearch. It
+// is a naive stand-in for the Elasticsearch multi_match query; filters, sorts
+// and facets follow the Elasticsearch repository.
+func (r *memoryRepository) SearchProducts(ctx context.Context, filter SearchFilter, skip uint64, take uint64) (*SearchResult, error) {
+	terms := strings.Fields(strings.ToLower(filter.Query))
+	r.mu.RLock()
+	type match struct {
+		product Product
+		score   int
+	}
+	matches := []match{}
+	for _, p := range r.products {
+		if !filter.matchesPrice(p.Price) || !filter.matchesCategories(p.Categories) {
+			continue
+		}
+		words := strings.Fields(strings.ToLower(p.Name + " " + p.Description))
+		score := 0
+		for _, term := range terms {
+			for _, word := range words {
+				if matchesTerm(word, term) {
+					score++
+					break
+				}
+			}
+		}
+		if score > 0 || len(terms) == 0 {
+			matches = append(matches, match{p, score})
+		}
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i].product, matches[j].product
+		switch filter.Sort {
+		case SortPriceAsc, SortPriceDesc:
+			if a.Price.Currency != b.Price.Currency {
+				return a.Price.Currency < b.Price.Currency
+			}
+			if a.Price.Amount != b.Price.Amount {
+				return (a.Price.Amount < b.Price.Amount) == (filter.Sort == SortPriceAsc)
+			}
+		case SortNewest:
+			// KSUIDs start with their creation time
+			return a.ID > b.ID
+		default:
+			if matches[i].score != matches[j].score {
+				return matches[i].score > matches[j].score
+			}
+		}
+		return a.ID < b.ID
+	})
+	result := &SearchResult{Products: []Product{}, Total: uint64(len(matches))}
+	categoryCounts := map[string]uint64{}
+	priceCounts := map[string][]uint64{}
+	for _, m := range matches {
+		result.Products = append(result.Products, m.product)
+		for _, c := range m.product.Categories {
+			categoryCounts[c]++
+		}
+		currency := m.product.Price.Currency
+		edges := priceBuckets(money.Exponent(currency))
+		if priceCounts[currency] == nil {
+			priceCounts[currency] = make([]uint64, len(edges)+1)
+		}
+		priceCounts[currency][sort.Search(len(edges), func(i int) bool { return edges[i] > m.product.Price.Amount })]++
+	}
+	result.Products = page(result.Products, skip, take)
+
+	result.Facets.Categories = []CategoryFacet{}
+	for category, count := range categoryCounts {
+		result.Facets.Categories = append(result.Facets.Categories, CategoryFacet{Category: category, Count: count})
+	}
+	result.Facets.Categories = sortCategoryFacets(result.Facets.Categories)
+	result.Facets.PriceRanges = []PriceRangeFacet{}
+	currencies := []string{}
+	for currency := range priceCounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Strings(currencies)
+	for _, currency := range currencies {
+		edges := priceBuckets(money.Exponent(currency))
+		for bucket, count := range priceCounts[currency] {
+			if count > 0 {
+				result.Facets.PriceRanges = append(result.Facets.PriceRanges, priceRangeFacet(currency, edges, bucket, count))
+			}
+		}
+	}
+	return result, nil
+}
+
+// SuggestProducts ranks names starting with the prefix first, then shorter
+// names. Products with the same name are suggested once.
+func (r *memoryRepository) SuggestProducts(ctx context.Context, prefix string, size int) ([]Suggestion, error) {
+	r.mu.RLock()
+	suggestions := []Suggestion{}
+	seen := map[string]bool{}
+	for _, p := range r.products {
+		if seen[p.Name] {
+			continue
+		}
+		if highlight, ok := suggest(p.Name, prefix); ok {
+			seen[p.Name] = true
+			suggestions = append(suggestions, Suggestion{ProductID: p.ID, Name: p.Name, Highlight: highlight})
+		}
+	}
+	r.mu.RUnlock()
+
+	lowerPrefix := strings.ToLower(prefix)
+	sort.Slice(suggestions, func(i, j int) bool {
+		a, b := suggestions[i], suggestions[j]
+		aStarts := strings.HasPrefix(strings.ToLower(a.Name), lowerPrefix)
+		bStarts := strings.HasPrefix(strings.ToLower(b.Name), lowerPrefix)
+		if aStarts != bStarts {
+			return aStarts
+		}
+		if len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		return a.Name < b.Name
+	})
+	if len(suggestions) > size {
+		suggestions = suggestions[:size]
+	}
+	return suggestions, nil
+}
+
+func (r *memoryRepository) UpdateProduct(ctx context.Context, id string, fields map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	product, exists := r.products[id]
+	if !exists {
+		return ErrNotFound
+	}
+	if name, ok := fields["name"].(string); ok {
+		product.Name = name
+	}
+	if description, ok := fields["description"].(string); ok {
+		product.Description = description
+	}
+	if price, ok := fields["price"].(money.Money); ok {
+		product.Price = price
+	}
+	if weightGrams, ok := fields["weight_grams"].(uint32); ok {
+		product.WeightGrams = weightGrams
+	}
+	if categories, ok := fields["categories"].([]string); ok {
+		product.Categories = categories
+	}
+	r.products[id] = product
+	return nil
+}
+
+func (r *memoryRepository) DeleteProduct(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.products[id]; !exists {
+		return ErrNotFound
+	}
+	delete(r.products, id)
+	return nil
+}
+
+func (r *memoryRepository) SetStock(ctx context.Context, id string, stock uint32) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	product, exists := r.products[id]
+	if !exists {
+		return ErrNotFound
+	}
+	product.Stock = stock
+	r.products[id] = product
+	return nil
+}
+
+func (r *memoryRepository) AdjustStock(ctx context.Context, id string, delta int32) (uint32, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	product, exists := r.products[id]
+	if !exists {
+		return 0, ErrNotFound
+	}
+	if int64(product.Stock)+int64(delta) < 0 {
+		return product.Stock, ErrInsufficientStock
+	}
+	if int64(product.Stock)+int64(delta) > math.MaxUint32 {
+		return product.Stock, ErrStockOverflow
+	}
+	product.Stock = uint32(int64(product.Stock) + int64(delta))
+	r.products[id] = product
+	return product.Stock, nil
+}
+
+func (r *memoryRepository) ReserveIdempotencyKey(ctx context.Context, key string, productID string, ttl time.Duration) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, exists := r.idempotencyKeys[key]; exists && existing.ExpiresAt.After(time.Now()) {
+		return existing.ProductID, nil
+	}
+	r.idempotencyKeys[key] = idempotencyKeyDocument{ProductID: productID, ExpiresAt: time.Now().Add(ttl)}
+	return productID, nil
+}
+
+func (r *memoryRepository) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.idempotencyKeys, key)
+	return nil
+}
+
+func (r *memoryRepository) PutCategory(ctx context.Context, category Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.categories[category.Slug]; exists {
+		return ErrCategoryExists
+	}
+	r.categories[category.Slug] = category
+	return nil
+}
+
+func (r *memoryRepository) UpdateCategory(ctx context.Context, category Category) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.categories[category.Slug]; !exists {
+		return ErrCategoryNotFound
+	}
+	r.categories[category.Slug] = category
+	return nil
+}
+
+func (r *memoryRepository) DeleteCategory(ctx context.Context, slug string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.categories[slug]; !exists {
+		return ErrCategoryNotFound
+	}
+	delete(r.categories, slug)
+	return nil
+}
+
+func (r *memoryRepository) ListCategories(ctx context.Context) ([]Category, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	categories := []Category{}
+	for _, c := range r.categories {
+		categories = append(categories, c)
+	}
+	sort.Slice(categories, func(i, j int) bool {
+		return categories[i].Slug < categories[j].Slug
+	})
+	return categories, nil
+}
+
+// page returns the items selected by skip and take
+func page(products []Product, skip uint64, take uint64) []Product {
+	if skip >= uint64(len(products)) {
+		return []Product{}
+	}
+	products = products[skip:]
+	if take < uint64(len(products)) {
+		products = products[:take]
+	}
+	return products
+}
@@ -4,21 +4,45 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"html"
+	"io"
 	"log"
+	"math"
+	"time"
 
 	"github.com/olivere/elastic/v7"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
 )
 
-var ErrNotFound = errors.New("product not found")
+var (
+	ErrNotFound          = errs.NotFound("product not found")
+	ErrInsufficientStock = errs.FailedPrecondition("insufficient stock")
+	ErrStockOverflow     = errs.FailedPrecondition(fmt.Sprintf("stock cannot exceed %d", uint32(math.MaxUint32)))
+)
 
 type Repository interface {
 	Close()
 	Ping(ctx context.Context) error
 	PutProduct(ctx context.Context, product Product) error
+	PutProducts(ctx context.Context, products []ImportedProduct) ([]error, error)
 	GetProductByID(ctx context.Context, id string) (*Product, error)
 	ListAllProducts(ctx context.Context, skip uint64, take uint64) ([]Product, error)
 	ListProductsWithIDs(ctx context.Context, ids []string) ([]Product, error)
-	SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]Product, error)
+	ScanProducts(ctx context.Context, each func(Product) error) error
+	SearchProducts(ctx context.Context, filter SearchFilter, skip uint64, take uint64) (*SearchResult, error)
+	SuggestProducts(ctx context.Context, prefix string, size int) ([]Suggestion, error)
+	UpdateProduct(ctx context.Context, id string, fields map[string]interface{}) error
+	DeleteProduct(ctx context.Context, id string) error
+	SetStock(ctx context.Context, id string, stock uint32) error
+	AdjustStock(ctx context.Context, id string, delta int32) (uint32, error)
+	ReserveIdempotencyKey(ctx context.Context, key string, productID string, ttl time.Duration) (string, error)
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
+	PutCategory(ctx context.Context, category Category) error
+	UpdateCategory(ctx context.Context, category Category) error
+	DeleteCategory(ctx context.Context, slug string) error
+	ListCategories(ctx context.Context) ([]Category, error)
 }
 
 type elasticRepository struct {
@@ -26,11 +50,49 @@ type elasticRepository struct {
 }
 
 type productDocument struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Price       float64 `json:"price"`
+	Name          string `json:"name"`
+	Description   string `json:"description"`
+	PriceAmount   *int64 `json:"price_amount,omitempty"`
+	PriceCurrency string `json:"price_currency,omitempty"`
+	// LegacyPrice is the float price of documents written before prices were
+	// stored in minor units. It is only read, and removed when the price changes.
+	LegacyPrice *float64 `json:"price,omitempty"`
+	Stock       uint32   `json:"stock"`
+	WeightGrams uint32   `json:"weight_grams"`
+	Categories  []string `json:"categories"`
+	// CreatedAt sorts products by newest; documents written before it was
+	// recorded sort last
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// price returns the exact price of the document, converting a legacy float
+// price in the default currency if the document has not been migrated yet
+func (doc productDocument) price() money.Money {
+	if doc.PriceAmount != nil {
+		return money.Money{Amount: *doc.PriceAmount, Currency: doc.PriceCurrency}
+	}
+	if doc.LegacyPrice != nil {
+		return money.FromFloat(*doc.LegacyPrice, money.DefaultCurrency)
+	}
+	return money.Zero(money.DefaultCurrency)
+}
+
+func (doc productDocument) product(id string) Product {
+	return Product{
+		ID:          id,
+		Name:        doc.Name,
+		Description: doc.Description,
+		Price:       doc.price(),
+		Stock:       doc.Stock,
+		WeightGrams: doc.WeightGrams,
+		Categories:  doc.Categories,
+	}
 }
 
+type idempotencyKeyDocument struct {
+	ProductID string    `json:"product_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
 
 func NewElasticRepository(url string) (Repository, error) {
 	client, err := elastic.NewClient(
@@ -40,6 +102,11 @@ func NewElasticRepository(url string) (Repository, error) {
 	if err != nil {
 		return nil, err
 	}
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	if err := ensureProductsIndex(ctx, client); err != nil {
+		return nil, err
+	}
 	return &elasticRepository{client: client}, nil
 }
 
@@ -60,23 +127,75 @@ func (r *elasticRepository) Ping(ctx context.Context) error {
 }
 
 // PUT /products/_doc/123
-// Body: {"name": "iPhone", "description": "Smartphone", "price": "999.99"}
+// Body: {"name": "iPhone", "description": "Smartphone", "price_amount": 99999, "price_currency": "USD", "categories": ["phones"]}
 func (repo *elasticRepository) PutProduct(ctx context.Context, product Product) error {
-	doc := productDocument{
-		Name:        product.Name,
-		Description: product.Description,
-		Price:       product.Price,
-	}
 	_, err := repo.client.Index().
 		Index("products").
 		Id(product.ID).
-		BodyJson(doc).
+		BodyJson(newProductDocument(product)).
 		Do(ctx)
 	return err
 }
 
+// New products are created from the upsert document. Existing products only get
+// the fields of the partial document, so they keep their creation time, and
+// their stock when it is kept. Products are written independently; the error
+// of each is returned in the order of products, nil for the ones written.
+func (repo *elasticRepository) PutProducts(ctx context.Context, products []ImportedProduct) ([]error, error) {
+	productErrs := make([]error, len(products))
+	if len(products) == 0 {
+		return productErrs, nil
+	}
+	bulk := repo.client.Bulk().Index("products")
+	for _, product := range products {
+		fields := map[string]interface{}{
+			"name":           product.Name,
+			"description":    product.Description,
+			"price_amount":   product.Price.Amount,
+			"price_currency": product.Price.Currency,
+			// Drop the legacy float price so it cannot shadow the new one
+			"price":        nil,
+			"weight_grams": product.WeightGrams,
+			"categories":   product.Categories,
+		}
+		if !product.KeepStock {
+			fields["stock"] = product.Stock
+		}
+		bulk.Add(elastic.NewBulkUpdateRequest().
+			Id(product.ID).
+			Doc(fields).
+			Upsert(newProductDocument(product.Product)))
+	}
+	res, err := bulk.Do(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	// Items come back in the order of the requests
+	for i, item := range res.Updated() {
+		if item.Error != nil {
+			productErrs[i] = fmt.Errorf("%s: %s", item.Error.Type, item.Error.Reason)
+		}
+	}
+	return productErrs, nil
+}
+
+func newProductDocument(product Product) productDocument {
+	createdAt := time.Now().UTC()
+	return productDocument{
+		Name:          product.Name,
+		Description:   product.Description,
+		PriceAmount:   &product.Price.Amount,
+		PriceCurrency: product.Price.Currency,
+		Stock:         product.Stock,
+		WeightGrams:   product.WeightGrams,
+		Categories:    product.Categories,
+		CreatedAt:     &createdAt,
+	}
+}
+
 // GET /products/_doc/123
-// Returns: {"_id": "123", "_source": {"name": "iPhone", "price": "999.99"}}
+// Returns: {"_id": "123", "_source": {"name": "iPhone", "price_amount": 99999, "price_currency": "USD"}}
 func (repo *elasticRepository) GetProductByID(ctx context.Context, id string) (*Product, error) {
 	res, err := repo.client.Get().
 		Index("products").
@@ -93,13 +212,8 @@ func (repo *elasticRepository) GetProductByID(ctx context.Context, id string) (*
 	if err := json.Unmarshal(res.Source, &doc); err != nil {
 		return nil, err
 	}
-
-	return &Product{
-		ID:          id,
-		Name:        doc.Name,
-		Description: doc.Description,
-		Price:       doc.Price,
-	}, nil
+	product := doc.product(id)
+	return &product, nil
 }
 
 // GET /products/_search
@@ -119,12 +233,7 @@ func (repo *elasticRepository) ListAllProducts(ctx context.Context, skip uint64,
 	for _, hit := range res.Hits.Hits {
 		p := productDocument{}
 		if err = json.Unmarshal(hit.Source, &p); err == nil {
-			products = append(products, Product{
-				ID:          hit.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+			products = append(products, p.product(hit.Id))
 		}
 	}
 	return products, nil
@@ -154,41 +263,416 @@ func (repo *elasticRepository) ListProductsWithIDs(ctx context.Context, ids []st
 	for _, doc := range res.Docs {
 		p := productDocument{}
 		if err = json.Unmarshal(doc.Source, &p); err == nil {
-			products = append(products, Product{
-				ID:          doc.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+			products = append(products, p.product(doc.Id))
 		}
 	}
 	return products, nil
 }
 
+// Pages through every product in index order, the cheapest order to read, and
+// calls each with every product until it returns an error.
+func (repo *elasticRepository) ScanProducts(ctx context.Context, each func(Product) error) error {
+	scroll := repo.client.Scroll("products").
+		Sort("_doc", true).
+		Size(exportBatchSize).
+		KeepAlive("1m")
+	defer scroll.Clear(context.WithoutCancel(ctx))
+	for {
+		res, err := scroll.Do(ctx)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		for _, hit := range res.Hits.Hits {
+			p := productDocument{}
+			if err = json.Unmarshal(hit.Source, &p); err != nil {
+				continue
+			}
+			if err = each(p.product(hit.Id)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
 // GET /products/_search
-// Body: {"query": {"multi_match": {"query": "phone", "fields": ["name", "description"]}}, "from": 0, "size": 10}
-// Returns: {"hits": {"hits": [{"_id": "123", "_source": {"name": "iPhone"}}]}}
-func (repo *elasticRepository) SearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]Product, error) {
+// Body: {"query": {"bool": {"must": {"multi_match": {"query": "phone", "fields": ["name", "description"], "fuzziness": "AUTO"}}}}, "from": 0, "size": 10}
+// Returns: {"hits": {"hits": [{"_id": "123", "_source": {"name": "iPhone"}}]}, "aggregations": {...}}
+func (repo *elasticRepository) SearchProducts(ctx context.Context, filter SearchFilter, skip uint64, take uint64) (*SearchResult, error) {
+	// A bool query without clauses matches every product
+	query := elastic.NewBoolQuery()
+	if filter.Query != "" {
+		// AUTO tolerates one typo in terms of 3 to 5 characters and two in longer ones
+		query.Must(elastic.NewMultiMatchQuery(filter.Query, "name", "description").Fuzziness("AUTO"))
+	}
+	if currency := filter.priceCurrency(); currency != "" {
+		priceRange := elastic.NewRangeQuery("price_amount")
+		if filter.MinPrice != nil {
+			priceRange.Gte(filter.MinPrice.Amount)
+		}
+		if filter.MaxPrice != nil {
+			priceRange.Lte(filter.MaxPrice.Amount)
+		}
+		query.Filter(elastic.NewTermQuery("price_currency", currency), priceRange)
+	}
+	if len(filter.Categories) > 0 {
+		query.Filter(elastic.NewTermsQueryFromStrings("categories", filter.Categories...))
+	}
+
+	search := repo.client.Search().
+		Index("products").
+		Query(query).
+		TrackTotalHits(true).
+		Aggregation("categories", elastic.NewTermsAggregation().Field("categories").Size(maxCategoryFacets)).
+		From(int(skip)).Size(int(take))
+	for _, exponent := range currencyExponents {
+		search = search.Aggregation(priceAggregationName(exponent), priceAggregation(exponent))
+	}
+	switch filter.Sort {
+	case SortPriceAsc, SortPriceDesc:
+		search = search.SortBy(
+			elastic.NewFieldSort("price_currency").UnmappedType("keyword"),
+			elastic.NewFieldSort("price_amount").Order(filter.Sort == SortPriceAsc).UnmappedType("long"),
+		)
+	case SortNewest:
+		search = search.SortBy(elastic.NewFieldSort("created_at").Desc().UnmappedType("date"))
+	}
+	res, err := search.Do(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := &SearchResult{
+		Products: []Product{},
+		Total:    uint64(res.TotalHits()),
+		Facets:   Facets{PriceRanges: []PriceRangeFacet{}, Categories: []CategoryFacet{}},
+	}
+	for _, hit := range res.Hits.Hits {
+		p := productDocument{}
+		if err = json.Unmarshal(hit.Source, &p); err == nil {
+			result.Products = append(result.Products, p.product(hit.Id))
+		}
+	}
+	if categories, ok := res.Aggregations.Terms("categories"); ok {
+		for _, bucket := range categories.Buckets {
+			if category, ok := bucket.Key.(string); ok {
+				result.Facets.Categories = append(result.Facets.Categories, CategoryFacet{Category: category, Count: uint64(bucket.DocCount)})
+			}
+		}
+	}
+	for _, exponent := range currencyExponents {
+		group, ok := res.Aggregations.Filter(priceAggregationName(exponent))
+		if !ok {
+			continue
+		}
+		currencies, ok := group.Terms("currencies")
+		if !ok {
+			continue
+		}
+		edges := priceBuckets(exponent)
+		for _, currencyBucket := range currencies.Buckets {
+			currency, _ := currencyBucket.Key.(string)
+			ranges, ok := currencyBucket.Range("prices")
+			if !ok {
+				continue
+			}
+			// Range buckets come back in the order of the edges
+			for i, bucket := range ranges.Buckets {
+				if bucket.DocCount > 0 {
+					result.Facets.PriceRanges = append(result.Facets.PriceRanges, priceRangeFacet(currency, edges, i, uint64(bucket.DocCount)))
+				}
+			}
+		}
+	}
+	result.Facets.PriceRanges = sortPriceRangeFacets(result.Facets.PriceRanges)
+	return result, nil
+}
+
+// Every term but the last must match a word of the name, the last may be the
+// start of one. Products with the same name are suggested once.
+func (repo *elasticRepository) SuggestProducts(ctx context.Context, prefix string, size int) ([]Suggestion, error) {
+	query := elastic.NewMultiMatchQuery(prefix, "name.suggest", "name.suggest._2gram", "name.suggest._3gram").
+		Type("bool_prefix")
+	highlight := elastic.NewHighlight().
+		Field("name").
+		HighlightQuery(elastic.NewMatchBoolPrefixQuery("name", prefix)).
+		PreTags(highlightPreTag).
+		PostTags(highlightPostTag).
+		Encoder("html").
+		NumOfFragments(0)
 	res, err := repo.client.Search().
 		Index("products").
-		Query(elastic.NewMultiMatchQuery(query, "name", "description")).
-		From(int(skip)).Size(int(take)).
+		Query(query).
+		Collapse(elastic.NewCollapseBuilder("name.keyword")).
+		Highlight(highlight).
+		FetchSourceContext(elastic.NewFetchSourceContext(true).Include("name")).
+		Size(size).
 		Do(ctx)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
-	products := []Product{}
+	suggestions := []Suggestion{}
 	for _, hit := range res.Hits.Hits {
 		p := productDocument{}
-		if err = json.Unmarshal(hit.Source, &p); err == nil {
-			products = append(products, Product{
-				ID:          hit.Id,
-				Name:        p.Name,
-				Description: p.Description,
-				Price:       p.Price,
-			})
+		if err = json.Unmarshal(hit.Source, &p); err != nil {
+			continue
+		}
+		suggestion := Suggestion{ProductID: hit.Id, Name: p.Name, Highlight: html.EscapeString(p.Name)}
+		if fragments := hit.Highlight["name"]; len(fragments) > 0 {
+			suggestion.Highlight = fragments[0]
+		}
+		suggestions = append(suggestions, suggestion)
+	}
+	return suggestions, nil
+}
+
+func priceAggregationName(exponent int) string {
+	return fmt.Sprintf("prices_%d", exponent)
+}
+
+// priceAggregation counts the products priced in currencies with the given
+// number of decimal places per currency and price range. The edges of the
+// ranges are in minor units, so every number of decimal places needs its own
+// aggregation.
+func priceAggregation(exponent int) elastic.Aggregation {
+	var currencies elastic.Query
+	if exponent == 2 {
+		// Every currency that is not listed with another number of decimal places
+		others := []string{}
+		for _, e := range currencyExponents {
+			if e != 2 {
+				others = append(others, money.WithExponent(e)...)
+			}
+		}
+		currencies = elastic.NewBoolQuery().MustNot(elastic.NewTermsQueryFromStrings("price_currency", others...))
+	} else {
+		currencies = elastic.NewTermsQueryFromStrings("price_currency", money.WithExponent(exponent)...)
+	}
+	edges := priceBuckets(exponent)
+	prices := elastic.NewRangeAggregation().Field("price_amount").AddUnboundedFrom(edges[0])
+	for i := 1; i < len(edges); i++ {
+		prices = prices.AddRange(edges[i-1], edges[i])
+	}
+	prices = prices.AddUnboundedTo(edges[len(edges)-1])
+	return elastic.NewFilterAggregation().
+		Filter(currencies).
+		SubAggregation("currencies", elastic.NewTermsAggregation().
+			Field("price_currency").
+			Size(maxCurrencyFacets).
+			SubAggregation("prices", prices))
+}
+
+// Only the given fields are changed, the rest of the document is kept.
+func (repo *elasticRepository) UpdateProduct(ctx context.Context, id string, fields map[string]interface{}) error {
+	if price, ok := fields["price"].(money.Money); ok {
+		fields["price_amount"] = price.Amount
+		fields["price_currency"] = price.Currency
+		// Drop the legacy float price so it cannot shadow the new one
+		fields["price"] = nil
+	}
+	_, err := repo.client.Update().
+		Index("products").
+		Id(id).
+		Doc(fields).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (repo *elasticRepository) DeleteProduct(ctx context.Context, id string) error {
+	_, err := repo.client.Delete().
+		Index("products").
+		Id(id).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+func (repo *elasticRepository) SetStock(ctx context.Context, id string, stock uint32) error {
+	_, err := repo.client.Update().
+		Index("products").
+		Id(id).
+		Doc(map[string]interface{}{"stock": stock}).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return ErrNotFound
+	}
+	return err
+}
+
+// The script runs atomically on the document, so concurrent adjustments cannot
+// drive the stock below zero or past MaxUint32. A rejected adjustment is a noop
+// and returns ErrInsufficientStock or ErrStockOverflow together with the current
+// stock.
+func (repo *elasticRepository) AdjustStock(ctx context.Context, id string, delta int32) (uint32, error) {
+	script := elastic.NewScript(`
+		long stock = ctx._source.stock == null ? 0 : ctx._source.stock;
+		if (stock + params.delta < 0 || stock + params.delta > params.max) {
+			ctx.op = 'noop';
+		} else {
+			ctx._source.stock = stock + params.delta;
+		}`).
+		Param("delta", delta).
+		Param("max", uint32(math.MaxUint32))
+	res, err := repo.client.Update().
+		Index("products").
+		Id(id).
+		Script(script).
+		RetryOnConflict(3).
+		FetchSource(true).
+		Do(ctx)
+	if err != nil {
+		if elastic.IsNotFound(err) {
+			return 0, ErrNotFound
+		}
+		return 0, err
+	}
+
+	var doc productDocument
+	if res.GetResult != nil {
+		if err := json.Unmarshal(res.GetResult.Source, &doc); err != nil {
+			return 0, err
+		}
+	}
+	if res.Result == "noop" {
+		if delta > 0 {
+			return doc.Stock, ErrStockOverflow
+		}
+		return doc.Stock, ErrInsufficientStock
+	}
+	return doc.Stock, nil
+}
+
+// Binds key to productID unless an unexpired binding already exists, and returns
+// the product ID the key is bound to.
+func (repo *elasticRepository) ReserveIdempotencyKey(ctx context.Context, key string, productID string, ttl time.Duration) (string, error) {
+	doc := idempotencyKeyDocument{
+		ProductID: productID,
+		ExpiresAt: time.Now().Add(ttl),
+	}
+	_, err := repo.client.Index().
+		Index("product_idempotency_keys").
+		Id(key).
+		OpType("create").
+		Refresh("true").
+		BodyJson(doc).
+		Do(ctx)
+	if err == nil {
+		return productID, nil
+	}
+	if !elastic.IsConflict(err) {
+		return "", err
+	}
+
+	// The key already exists, reuse it unless it has expired
+	res, err := repo.client.Get().
+		Index("product_idempotency_keys").
+		Id(key).
+		Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	var existing idempotencyKeyDocument
+	if err := json.Unmarshal(res.Source, &existing); err != nil {
+		return "", err
+	}
+	if existing.ExpiresAt.After(time.Now()) {
+		return existing.ProductID, nil
+	}
+	_, err = repo.client.Index().
+		Index("product_idempotency_keys").
+		Id(key).
+		IfSeqNo(*res.SeqNo).
+		IfPrimaryTerm(*res.PrimaryTerm).
+		BodyJson(doc).
+		Do(ctx)
+	if err != nil {
+		return "", err
+	}
+	return productID, nil
+}
+
+func (repo *elasticRepository) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	_, err := repo.client.Delete().
+		Index("product_idempotency_keys").
+		Id(key).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Category writes wait for the refresh, so the next ListCategories sees them.
+func (repo *elasticRepository) PutCategory(ctx context.Context, category Category) error {
+	_, err := repo.client.Index().
+		Index("categories").
+		Id(category.Slug).
+		OpType("create").
+		Refresh("wait_for").
+		BodyJson(category).
+		Do(ctx)
+	if elastic.IsConflict(err) {
+		return ErrCategoryExists
+	}
+	return err
+}
+
+func (repo *elasticRepository) UpdateCategory(ctx context.Context, category Category) error {
+	_, err := repo.client.Update().
+		Index("categories").
+		Id(category.Slug).
+		Doc(map[string]interface{}{"name": category.Name, "parent": category.Parent}).
+		Refresh("wait_for").
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return ErrCategoryNotFound
+	}
+	return err
+}
+
+func (repo *elasticRepository) DeleteCategory(ctx context.Context, slug string) error {
+	_, err := repo.client.Delete().
+		Index("categories").
+		Id(slug).
+		Refresh("wait_for").
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return ErrCategoryNotFound
+	}
+	return err
+}
+
+// The categories index is created with the first category, so until then there
+// are no categories.
+func (repo *elasticRepository) ListCategories(ctx context.Context) ([]Category, error) {
+	res, err := repo.client.Search().
+		Index("categories").
+		Query(elastic.NewMatchAllQuery()).
+		SortBy(elastic.NewFieldSort("slug.keyword").UnmappedType("keyword")).
+		Size(maxCategories).
+		Do(ctx)
+	if elastic.IsNotFound(err) {
+		return []Category{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	categories := []Category{}
+	for _, hit := range res.Hits.Hits {
+		c := Category{}
+		if err = json.Unmarshal(hit.Source, &c); err == nil {
+			categories = append(categories, c)
 		}
 	}
-	return products, err
+	return categories, nil
 }
@@ -0,0 +1,235 @@
+package product
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
+)
+
+// SearchSort orders the products of a search
+type SearchSort string
+
+const (
+	// SortRelevance ranks the best matches of the query first; without a query
+	// the order is unspecified
+	SortRelevance SearchSort = "relevance"
+	// SortPriceAsc and SortPriceDesc group products by currency and order them
+	// by price within it
+	SortPriceAsc  SearchSort = "price_asc"
+	SortPriceDesc SearchSort = "price_desc"
+	SortNewest    SearchSort = "newest"
+)
+
+func (s SearchSort) valid() bool {
+	return s == SortRelevance || s == SortPriceAsc || s == SortPriceDesc || s == SortNewest
+}
+
+const (
+	// maxCategoryFacets bounds the number of categories counted by a search
+	maxCategoryFacets = 50
+	// maxCurrencyFacets bounds the number of currencies whose prices are counted
+	maxCurrencyFacets = 200
+)
+
+// priceBucketEdges split prices into the ranges counted by a search, in major
+// units of the currency: under 10, 10 to 25, ..., 1000 and over
+var priceBucketEdges = []int64{10, 25, 50, 100, 250, 500, 1000}
+
+// categorySlug is the form of category slugs, e.g. "smart-phones"
+var categorySlug = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// SearchFilter selects the products of a search. Empty fields select every
+// product; a product matches if it has any of the Categories.
+type SearchFilter struct {
+	Query      string
+	MinPrice   *money.Money
+	MaxPrice   *money.Money
+	Categories []string
+	Sort       SearchSort
+}
+
+// priceCurrency returns the currency of the price range, or an empty string if
+// the filter has none
+func (f SearchFilter) priceCurrency() string {
+	if f.MinPrice != nil {
+		return f.MinPrice.Currency
+	}
+	if f.MaxPrice != nil {
+		return f.MaxPrice.Currency
+	}
+	return ""
+}
+
+// matchesPrice reports whether price is within the price range of the filter.
+// A price range only matches prices in its own currency.
+func (f SearchFilter) matchesPrice(price money.Money) bool {
+	currency := f.priceCurrency()
+	if currency == "" {
+		return true
+	}
+	if price.Currency != currency {
+		return false
+	}
+	return (f.MinPrice == nil || price.Amount >= f.MinPrice.Amount) &&
+		(f.MaxPrice == nil || price.Amount <= f.MaxPrice.Amount)
+}
+
+// matchesCategories reports whether any of categories is one of the filter's
+func (f SearchFilter) matchesCategories(categories []string) bool {
+	if len(f.Categories) == 0 {
+		return true
+	}
+	for _, c := range categories {
+		for _, wanted := range f.Categories {
+			if c == wanted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// PriceRangeFacet counts the products priced from From up to, but not
+// including, To. From is nil for the lowest range and To for the highest.
+type PriceRangeFacet struct {
+	From  *money.Money
+	To    *money.Money
+	Count uint64
+}
+
+type CategoryFacet struct {
+	Category string
+	Count    uint64
+}
+
+// Facets count the products matching a search by price range, per currency,
+// and by category. Ranges and categories without products are left out.
+type Facets struct {
+	PriceRanges []PriceRangeFacet
+	Categories  []CategoryFacet
+}
+
+// SearchResult is a page of the products matching a search; Total and Facets
+// count every matching product, not just the page
+type SearchResult struct {
+	Products []Product
+	Total    uint64
+	Facets   Facets
+}
+
+// currencyExponents are the numbers of decimal places of the currencies
+var currencyExponents = []int{0, 2, 3}
+
+// priceBuckets returns the edges of the price ranges in the minor units of
+// currencies with the given number of decimal places
+func priceBuckets(exponent int) []int64 {
+	scale := int64(math.Pow10(exponent))
+	edges := []int64{}
+	for _, edge := range priceBucketEdges {
+		edges = append(edges, edge*scale)
+	}
+	return edges
+}
+
+// priceRangeFacet returns the range of the bucket of edges with the given index,
+// where bucket 0 is below the first edge
+func priceRangeFacet(currency string, edges []int64, bucket int, count uint64) PriceRangeFacet {
+	facet := PriceRangeFacet{Count: count}
+	if bucket > 0 {
+		facet.From = &money.Money{Amount: edges[bucket-1], Currency: currency}
+	}
+	if bucket < len(edges) {
+		facet.To = &money.Money{Amount: edges[bucket], Currency: currency}
+	}
+	return facet
+}
+
+// sortPriceRangeFacets orders price ranges by currency, keeping the ranges of a
+// currency from lowest to highest
+func sortPriceRangeFacets(facets []PriceRangeFacet) []PriceRangeFacet {
+	sort.SliceStable(facets, func(i, j int) bool {
+		return facetCurrency(facets[i]) < facetCurrency(facets[j])
+	})
+	return facets
+}
+
+func facetCurrency(facet PriceRangeFacet) string {
+	if facet.From != nil {
+		return facet.From.Currency
+	}
+	return facet.To.Currency
+}
+
+// sortCategoryFacets orders categories by count, then by name, and keeps the
+// first maxCategoryFacets, like the Elasticsearch terms aggregation
+func sortCategoryFacets(facets []CategoryFacet) []CategoryFacet {
+	sort.Slice(facets, func(i, j int) bool {
+		if facets[i].Count != facets[j].Count {
+			return facets[i].Count > facets[j].Count
+		}
+		return facets[i].Category < facets[j].Category
+	})
+	if len(facets) > maxCategoryFacets {
+		facets = facets[:maxCategoryFacets]
+	}
+	return facets
+}
+
+// validateSearchFilter normalizes the currencies and categories of the filter
+// and rejects price ranges in two currencies or ending before they start
+func validateSearchFilter(filter SearchFilter) (SearchFilter, error) {
+	filter.Query = strings.TrimSpace(filter.Query)
+	if filter.Sort == "" {
+		filter.Sort = SortRelevance
+	}
+	if !filter.Sort.valid() {
+		return SearchFilter{}, fmt.Errorf("%w: unknown sort %q", ErrInvalidSearch, filter.Sort)
+	}
+	for _, price := range []**money.Money{&filter.MinPrice, &filter.MaxPrice} {
+		if *price == nil {
+			continue
+		}
+		normalized, err := validatePrice(**price)
+		if err != nil {
+			return SearchFilter{}, err
+		}
+		*price = &normalized
+	}
+	if filter.MinPrice != nil && filter.MaxPrice != nil {
+		if filter.MinPrice.Currency != filter.MaxPrice.Currency {
+			return SearchFilter{}, fmt.Errorf("%w: min and max price must be in the same currency", ErrInvalidSearch)
+		}
+		if filter.MinPrice.Amount > filter.MaxPrice.Amount {
+			return SearchFilter{}, fmt.Errorf("%w: min price must not exceed max price", ErrInvalidSearch)
+		}
+	}
+	categories, err := normalizeCategories(filter.Categories)
+	if err != nil {
+		return SearchFilter{}, err
+	}
+	filter.Categories = categories
+	return filter, nil
+}
+
+// normalizeCategories lower-cases and deduplicates category slugs, keeping
+// their order
+func normalizeCategories(categories []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, c := range categories {
+		c = strings.ToLower(strings.TrimSpace(c))
+		if !categorySlug.MatchString(c) {
+			return nil, errs.InvalidArgument(fmt.Sprintf("%q is not a category slug", c))
+		}
+		if !seen[c] {
+			seen[c] = true
+			normalized = append(normalized, c)
+		}
+	}
+	return normalized, nil
+}
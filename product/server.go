@@ -2,43 +2,82 @@ package product
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
+	"math"
 	"net"
 
+	"github.com/olivere/elastic/v7"
+	"github.com/sdshah09/GoCore/auth"
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
 	"github.com/sdshah09/GoCore/product/pb"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// stockViolationType marks precondition violations caused by insufficient stock
+const stockViolationType = "STOCK"
+
 type grpcServer struct {
 	pb.UnimplementedProductServiceServer
 	service Service
 }
 
-func ListenGRPC(s Service, port int) error {
+// policy opens the catalog and its categories to everyone and their changes,
+// and bulk imports and exports, to admins. Stock is only reserved and released
+// by the order service.
+var policy = auth.Policy{
+	pb.ProductService_PostProduct_FullMethodName:     auth.Admin,
+	pb.ProductService_GetProduct_FullMethodName:      auth.Public,
+	pb.ProductService_GetProducts_FullMethodName:     auth.Public,
+	pb.ProductService_SuggestProducts_FullMethodName: auth.Public,
+	pb.ProductService_UpdateProduct_FullMethodName:   auth.Admin,
+	pb.ProductService_DeleteProduct_FullMethodName:   auth.Admin,
+	pb.ProductService_SetStock_FullMethodName:        auth.Admin,
+	pb.ProductService_AdjustStock_FullMethodName:     auth.Admin,
+	pb.ProductService_ReserveStock_FullMethodName:    auth.Internal,
+	pb.ProductService_ReleaseStock_FullMethodName:    auth.Internal,
+	pb.ProductService_ImportProducts_FullMethodName:  auth.Admin,
+	pb.ProductService_ExportProducts_FullMethodName:  auth.Admin,
+	pb.ProductService_PostCategory_FullMethodName:    auth.Admin,
+	pb.ProductService_GetCategories_FullMethodName:   auth.Public,
+	pb.ProductService_UpdateCategory_FullMethodName:  auth.Admin,
+	pb.ProductService_DeleteCategory_FullMethodName:  auth.Admin,
+}
+
+func ListenGRPC(s Service, tokens *auth.TokenManager, port int) error {
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
 	if err != nil {
 		return err
 	}
-	serv := grpc.NewServer()
+	serv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(
+			errs.UnaryServerInterceptor,
+			auth.UnaryServerInterceptor(tokens, policy),
+			elasticErrorInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			errs.StreamServerInterceptor,
+			auth.StreamServerInterceptor(tokens, policy),
+			elasticErrorStreamInterceptor,
+		),
+	)
 	pb.RegisterProductServiceServer(serv, &grpcServer{service: s})
 	return serv.Serve(lis)
 }
 
 func (server *grpcServer) PostProduct(ctx context.Context, r *pb.PostProductRequest) (*pb.PostProductResponse, error) {
-	product, err := server.service.PostProduct(ctx, r.Name, r.Description, r.Price)
+	product, err := server.service.PostProduct(ctx, r.Name, r.Description, moneyFromProto(r.Price), r.Stock, r.WeightGrams, r.Categories, r.IdempotencyKey)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
-	return &pb.PostProductResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Description: product.Description,
-			Price:       product.Price,
-			Name:        product.Name,
-		},
-	}, nil
+	return &pb.PostProductResponse{Product: productToProto(product)}, nil
 }
 
 func (server *grpcServer) GetProduct(ctx context.Context, r *pb.GetProductRequest) (*pb.GetProductResponse, error) {
@@ -47,39 +86,349 @@ func (server *grpcServer) GetProduct(ctx context.Context, r *pb.GetProductReques
 		log.Println(err)
 		return nil, err
 	}
-	return &pb.GetProductResponse{
-		Product: &pb.Product{
-			Id:          product.ID,
-			Description: product.Description,
-			Name:        product.Name,
-			Price:       product.Price,
-		},
-	}, nil
+	return &pb.GetProductResponse{Product: productToProto(product)}, nil
 }
 
 func (server *grpcServer) GetProducts(ctx context.Context, r *pb.GetProductsRequest) (*pb.GetProductsResponse, error) {
-	var res []Product
-	var err error
-	if r.Query != "" {
-		res, err = server.service.GetSearchProducts(ctx, r.Query, r.Skip, r.Take)
-	} else if len(r.Ids) != 0 {
-		res, err = server.service.GetProductsWithIds(ctx, r.Ids)
-	} else {
-		res, err = server.service.GetAllProducts(ctx, r.Skip, r.Take)
+	if len(r.Ids) != 0 {
+		res, err := server.service.GetProductsWithIds(ctx, r.Ids)
+		if err != nil {
+			log.Println(err)
+			return nil, err
+		}
+		return &pb.GetProductsResponse{Products: productsToProto(res)}, nil
+	}
+
+	filter := SearchFilter{
+		Query:      r.Query,
+		Categories: r.Categories,
+		Sort:       SearchSort(r.Sort),
+	}
+	if r.MinPrice != nil {
+		minPrice := moneyFromProto(r.MinPrice)
+		filter.MinPrice = &minPrice
 	}
+	if r.MaxPrice != nil {
+		maxPrice := moneyFromProto(r.MaxPrice)
+		filter.MaxPrice = &maxPrice
+	}
+	result, err := server.service.SearchProducts(ctx, filter, r.Skip, r.Take)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.GetProductsResponse{
+		Products: productsToProto(result.Products),
+		Total:    result.Total,
+		Facets:   facetsToProto(result.Facets),
+	}, nil
+}
+
+func (server *grpcServer) SuggestProducts(ctx context.Context, r *pb.SuggestProductsRequest) (*pb.SuggestProductsResponse, error) {
+	suggestions, err := server.service.SuggestProducts(ctx, r.Prefix, r.Size)
 	if err != nil {
 		log.Println(err)
 		return nil, err
 	}
-	products := []*pb.Product{}
-	for _, p := range res {
-		products = append(products, &pb.Product{
-			Id:          p.ID,
-			Name:        p.Name,
-			Description: p.Description,
-			Price:       p.Price,
+	res := &pb.SuggestProductsResponse{Suggestions: []*pb.ProductSuggestion{}}
+	for _, s := range suggestions {
+		res.Suggestions = append(res.Suggestions, &pb.ProductSuggestion{
+			ProductId: s.ProductID,
+			Name:      s.Name,
+			Highlight: s.Highlight,
 		})
 	}
+	return res, nil
+}
+
+func (server *grpcServer) UpdateProduct(ctx context.Context, r *pb.UpdateProductRequest) (*pb.UpdateProductResponse, error) {
+	if r.UpdateMask == nil || len(r.UpdateMask.Paths) == 0 {
+		return nil, errs.InvalidArgument("update_mask must list at least one field")
+	}
+	if r.Product == nil {
+		r.Product = &pb.Product{}
+	}
+	update := ProductUpdate{}
+	for _, path := range r.UpdateMask.Paths {
+		switch path {
+		case "name":
+			update.Name = &r.Product.Name
+		case "description":
+			update.Description = &r.Product.Description
+		case "price":
+			price := moneyFromProto(r.Product.Price)
+			update.Price = &price
+		case "weight_grams":
+			update.WeightGrams = &r.Product.WeightGrams
+		case "categories":
+			update.Categories = &r.Product.Categories
+		default:
+			return nil, errs.InvalidArgument(fmt.Sprintf("field %q cannot be updated", path))
+		}
+	}
+	product, err := server.service.UpdateProduct(ctx, r.Id, update)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.UpdateProductResponse{Product: productToProto(product)}, nil
+}
+
+func (server *grpcServer) DeleteProduct(ctx context.Context, r *pb.DeleteProductRequest) (*pb.DeleteProductResponse, error) {
+	if err := server.service.DeleteProduct(ctx, r.Id); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.DeleteProductResponse{}, nil
+}
 
-	return &pb.GetProductsResponse{Products: products}, nil
+func (server *grpcServer) SetStock(ctx context.Context, r *pb.SetStockRequest) (*pb.SetStockResponse, error) {
+	product, err := server.service.SetStock(ctx, r.Id, r.Stock)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.SetStockResponse{Product: productToProto(product)}, nil
+}
+
+func (server *grpcServer) AdjustStock(ctx context.Context, r *pb.AdjustStockRequest) (*pb.AdjustStockResponse, error) {
+	product, err := server.service.AdjustStock(ctx, r.Id, r.Delta)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.AdjustStockResponse{Product: productToProto(product)}, nil
+}
+
+func (server *grpcServer) ReserveStock(ctx context.Context, r *pb.ReserveStockRequest) (*pb.ReserveStockResponse, error) {
+	items, err := stockItemsFromProto(r.Items)
+	if err != nil {
+		return nil, err
+	}
+	err = server.service.ReserveStock(ctx, items)
+	if err != nil {
+		log.Println(err)
+		var stockErr *InsufficientStockError
+		if errors.As(err, &stockErr) {
+			return nil, insufficientStockStatus(stockErr)
+		}
+		return nil, err
+	}
+	return &pb.ReserveStockResponse{}, nil
+}
+
+func (server *grpcServer) ReleaseStock(ctx context.Context, r *pb.ReleaseStockRequest) (*pb.ReleaseStockResponse, error) {
+	items, err := stockItemsFromProto(r.Items)
+	if err != nil {
+		return nil, err
+	}
+	if err := server.service.ReleaseStock(ctx, items); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.ReleaseStockResponse{}, nil
+}
+
+// ImportProducts imports the streamed products in batches, and reports the ones
+// that were not imported once the stream ends. An error ends the import; the
+// batches imported before it are kept.
+func (server *grpcServer) ImportProducts(stream pb.ProductService_ImportProductsServer) error {
+	res := &pb.ImportProductsResponse{Errors: []*pb.ImportError{}}
+	batch := []ImportedProduct{}
+	row := uint64(0)
+	importBatch := func() error {
+		productErrs, err := server.service.ImportProducts(stream.Context(), batch)
+		if err != nil {
+			log.Println(err)
+			return err
+		}
+		first := row - uint64(len(batch)) + 1
+		for i, err := range productErrs {
+			if err == nil {
+				res.Imported++
+				continue
+			}
+			res.Failed++
+			if len(res.Errors) < maxImportErrors {
+				res.Errors = append(res.Errors, &pb.ImportError{
+					Row:       first + uint64(i),
+					ProductId: batch[i].ID,
+					Message:   err.Error(),
+				})
+			}
+		}
+		batch = batch[:0]
+		return nil
+	}
+	for {
+		r, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if r.Product == nil {
+			r.Product = &pb.Product{}
+		}
+		row++
+		batch = append(batch, ImportedProduct{Product: *productFromProto(r.Product), KeepStock: r.KeepStock})
+		if len(batch) == importBatchSize {
+			if err := importBatch(); err != nil {
+				return err
+			}
+		}
+	}
+	if len(batch) > 0 {
+		if err := importBatch(); err != nil {
+			return err
+		}
+	}
+	return stream.SendAndClose(res)
+}
+
+func (server *grpcServer) ExportProducts(r *pb.ExportProductsRequest, stream pb.ProductService_ExportProductsServer) error {
+	err := server.service.ExportProducts(stream.Context(), func(p Product) error {
+		return stream.Send(&pb.ExportProductsResponse{Product: productToProto(&p)})
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	return err
+}
+
+func (server *grpcServer) PostCategory(ctx context.Context, r *pb.PostCategoryRequest) (*pb.PostCategoryResponse, error) {
+	c := r.GetCategory()
+	category, err := server.service.PostCategory(ctx, c.GetSlug(), c.GetName(), c.GetParent())
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.PostCategoryResponse{Category: categoryToProto(category)}, nil
+}
+
+func (server *grpcServer) GetCategories(ctx context.Context, r *pb.GetCategoriesRequest) (*pb.GetCategoriesResponse, error) {
+	categories, err := server.service.GetCategories(ctx)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	res := &pb.GetCategoriesResponse{}
+	for i := range categories {
+		res.Categories = append(res.Categories, categoryToProto(&categories[i]))
+	}
+	return res, nil
+}
+
+func (server *grpcServer) UpdateCategory(ctx context.Context, r *pb.UpdateCategoryRequest) (*pb.UpdateCategoryResponse, error) {
+	c := r.GetCategory()
+	category, err := server.service.UpdateCategory(ctx, c.GetSlug(), c.GetName(), c.GetParent())
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.UpdateCategoryResponse{Category: categoryToProto(category)}, nil
+}
+
+func (server *grpcServer) DeleteCategory(ctx context.Context, r *pb.DeleteCategoryRequest) (*pb.DeleteCategoryResponse, error) {
+	if err := server.service.DeleteCategory(ctx, r.Slug); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return &pb.DeleteCategoryResponse{}, nil
+}
+
+// elasticErrorInterceptor reports Elasticsearch connection failures as Unavailable
+func elasticErrorInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if elastic.IsConnErr(err) {
+		return res, errs.Unavailable("product search backend unavailable")
+	}
+	return res, err
+}
+
+func elasticErrorStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	if elastic.IsConnErr(err) {
+		return errs.Unavailable("product search backend unavailable")
+	}
+	return err
+}
+
+func productToProto(p *Product) *pb.Product {
+	return &pb.Product{
+		Id:          p.ID,
+		Name:        p.Name,
+		Description: p.Description,
+		Price:       moneyToProto(p.Price),
+		Stock:       p.Stock,
+		WeightGrams: p.WeightGrams,
+		Categories:  p.Categories,
+	}
+}
+
+func productsToProto(products []Product) []*pb.Product {
+	protoProducts := []*pb.Product{}
+	for i := range products {
+		protoProducts = append(protoProducts, productToProto(&products[i]))
+	}
+	return protoProducts
+}
+
+func facetsToProto(f Facets) *pb.Facets {
+	facets := &pb.Facets{}
+	for _, r := range f.PriceRanges {
+		priceRange := &pb.PriceRangeFacet{Count: r.Count}
+		if r.From != nil {
+			priceRange.From = moneyToProto(*r.From)
+		}
+		if r.To != nil {
+			priceRange.To = moneyToProto(*r.To)
+		}
+		facets.PriceRanges = append(facets.PriceRanges, priceRange)
+	}
+	for _, c := range f.Categories {
+		facets.Categories = append(facets.Categories, &pb.CategoryFacet{Category: c.Category, Count: c.Count})
+	}
+	return facets
+}
+
+func categoryToProto(c *Category) *pb.Category {
+	return &pb.Category{Slug: c.Slug, Name: c.Name, Parent: c.Parent}
+}
+
+func moneyToProto(m money.Money) *pb.Money {
+	return &pb.Money{Amount: m.Amount, Currency: m.Currency}
+}
+
+// stockItemsFromProto sums the quantities of each product. Sums that overflow
+// are rejected rather than wrapped.
+func stockItemsFromProto(items []*pb.StockItem) (map[string]uint32, error) {
+	quantities := map[string]uint32{}
+	for _, item := range items {
+		sum := uint64(quantities[item.ProductId]) + uint64(item.Quantity)
+		if item.Quantity == 0 || sum > math.MaxInt32 {
+			return nil, ErrInvalidQuantity
+		}
+		quantities[item.ProductId] = uint32(sum)
+	}
+	return quantities, nil
+}
+
+// insufficientStockStatus builds a FailedPrecondition status carrying one
+// violation per short product, so clients can rebuild the InsufficientStockError
+func insufficientStockStatus(stockErr *InsufficientStockError) error {
+	failure := &errdetails.PreconditionFailure{}
+	for _, s := range stockErr.Shortages {
+		failure.Violations = append(failure.Violations, &errdetails.PreconditionFailure_Violation{
+			Type:        stockViolationType,
+			Subject:     s.ProductID,
+			Description: fmt.Sprintf("requested %d, available %d", s.Requested, s.Available),
+		})
+	}
+	st, err := status.New(codes.FailedPrecondition, stockErr.Error()).WithDetails(failure)
+	if err != nil {
+		return status.Error(codes.FailedPrecondition, stockErr.Error())
+	}
+	return st.Err()
 }
@@ -2,23 +2,92 @@ package product
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/sdshah09/GoCore/errs"
+	"github.com/sdshah09/GoCore/money"
 	"github.com/segmentio/ksuid"
 )
 
+// idempotencyKeyTTL is how long a replayed PostProduct returns the original product
+const idempotencyKeyTTL = 24 * time.Hour
+
 type Product struct {
-	ID          string  `json:"id"`
-	Name        string  `json:"name"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price"`
+	Stock       uint32      `json:"stock"`
+	WeightGrams uint32      `json:"weight_grams"`
+	// Categories are the slugs of the categories the product is listed in
+	Categories []string `json:"categories"`
+}
+
+// ProductUpdate holds the fields to change in UpdateProduct; nil fields are left as is
+type ProductUpdate struct {
+	Name        *string
+	Description *string
+	Price       *money.Money
+	WeightGrams *uint32
+	Categories  *[]string
+}
+
+var (
+	ErrInvalidProduct = errs.InvalidArgument("invalid product")
+	ErrInvalidSearch  = errs.InvalidArgument("invalid search")
+	// Quantities are added to and taken from stock as an int32 delta
+	ErrInvalidQuantity = errs.InvalidArgument(fmt.Sprintf("quantity must be between 1 and %d", math.MaxInt32))
+)
+
+// StockShortage describes a product that cannot cover a requested quantity
+type StockShortage struct {
+	ProductID string
+	Requested uint32
+	Available uint32
+}
+
+// InsufficientStockError is returned when a reservation cannot be fulfilled.
+// It lists every product that is short, not just the first one.
+type InsufficientStockError struct {
+	Shortages []StockShortage
+}
+
+func (e *InsufficientStockError) Error() string {
+	details := []string{}
+	for _, s := range e.Shortages {
+		details = append(details, fmt.Sprintf("product %s: requested %d, available %d", s.ProductID, s.Requested, s.Available))
+	}
+	return "insufficient stock for " + strings.Join(details, "; ")
+}
+
+func (e *InsufficientStockError) Unwrap() error {
+	return ErrInsufficientStock
 }
 
 type Service interface {
-	PostProduct(ctx context.Context, name string, description string, price float64) (*Product, error)
+	PostProduct(ctx context.Context, name string, description string, price money.Money, stock uint32, weightGrams uint32, categories []string, idempotencyKey string) (*Product, error)
 	GetProduct(ctx context.Context, id string) (*Product, error)
 	GetAllProducts(ctx context.Context, skip uint64, take uint64) ([]Product, error)
 	GetProductsWithIds(ctx context.Context, ids []string) ([]Product, error)
-	GetSearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]Product, error)
+	ImportProducts(ctx context.Context, products []ImportedProduct) ([]error, error)
+	ExportProducts(ctx context.Context, each func(Product) error) error
+	SearchProducts(ctx context.Context, filter SearchFilter, skip uint64, take uint64) (*SearchResult, error)
+	SuggestProducts(ctx context.Context, prefix string, size uint32) ([]Suggestion, error)
+	UpdateProduct(ctx context.Context, id string, update ProductUpdate) (*Product, error)
+	DeleteProduct(ctx context.Context, id string) error
+	SetStock(ctx context.Context, id string, stock uint32) (*Product, error)
+	AdjustStock(ctx context.Context, id string, delta int32) (*Product, error)
+	ReserveStock(ctx context.Context, items map[string]uint32) error
+	ReleaseStock(ctx context.Context, items map[string]uint32) error
+	PostCategory(ctx context.Context, slug string, name string, parent string) (*Category, error)
+	GetCategories(ctx context.Context) ([]Category, error)
+	UpdateCategory(ctx context.Context, slug string, name string, parent string) (*Category, error)
+	DeleteCategory(ctx context.Context, slug string) error
 }
 
 type productService struct {
@@ -29,14 +98,38 @@ func NewService(repo Repository) Service {
 	return &productService{repo}
 }
 
-func (service *productService) PostProduct(ctx context.Context, name string, description string, price float64) (*Product, error) {
+func (service *productService) PostProduct(ctx context.Context, name string, description string, price money.Money, stock uint32, weightGrams uint32, categories []string, idempotencyKey string) (*Product, error) {
+	price, err := validatePrice(price)
+	if err != nil {
+		return nil, err
+	}
+	categories, err = service.checkCategories(ctx, categories)
+	if err != nil {
+		return nil, err
+	}
 	product := &Product{
 		Name:        name,
 		Description: description,
 		Price:       price,
+		Stock:       stock,
+		WeightGrams: weightGrams,
+		Categories:  categories,
 		ID:          ksuid.New().String(),
 	}
+	if idempotencyKey != "" {
+		reservedID, err := service.repository.ReserveIdempotencyKey(ctx, idempotencyKey, product.ID, idempotencyKeyTTL)
+		if err != nil {
+			return nil, err
+		}
+		if reservedID != product.ID {
+			// Replayed request, return the product created the first time
+			return service.repository.GetProductByID(ctx, reservedID)
+		}
+	}
 	if err := service.repository.PutProduct(ctx, *product); err != nil {
+		if idempotencyKey != "" {
+			service.repository.ReleaseIdempotencyKey(ctx, idempotencyKey)
+		}
 		return nil, err
 	}
 	return product, nil
@@ -59,9 +152,332 @@ func (service *productService) GetProductsWithIds(ctx context.Context, ids []str
 	res, err := service.repository.ListProductsWithIDs(ctx, ids)
 	return res, err
 }
-func (service *productService) GetSearchProducts(ctx context.Context, query string, skip uint64, take uint64) ([]Product, error) {
+
+// ImportProducts validates and stores a batch of imported products. Products
+// without an ID get a new one, products with a KSUID replace the product with
+// that ID, keeping its creation time. The error of each product is returned in the
+// order of products, nil for the ones imported; an invalid product does not stop
+// the others.
+func (service *productService) ImportProducts(ctx context.Context, products []ImportedProduct) ([]error, error) {
+	tree, err := service.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	productErrs := make([]error, len(products))
+	valid := []ImportedProduct{}
+	// Index in products of each valid product
+	rows := []int{}
+	for i, product := range products {
+		err = validateImportedID(product.ID)
+		if err == nil && strings.TrimSpace(product.Name) == "" {
+			err = fmt.Errorf("%w: name must not be empty", ErrInvalidProduct)
+		}
+		if err == nil {
+			product.Price, err = validatePrice(product.Price)
+		}
+		if err == nil {
+			product.Categories, err = normalizeCategories(product.Categories)
+		}
+		if err == nil {
+			err = tree.checkExist(product.Categories)
+		}
+		if err != nil {
+			productErrs[i] = err
+			continue
+		}
+		if product.ID == "" {
+			product.ID = ksuid.New().String()
+		}
+		valid = append(valid, product)
+		rows = append(rows, i)
+	}
+	putErrs, err := service.repository.PutProducts(ctx, valid)
+	if err != nil {
+		return nil, err
+	}
+	for i, err := range putErrs {
+		productErrs[rows[i]] = err
+	}
+	return productErrs, nil
+}
+
+// ExportProducts calls each with every product, in no particular order, until
+// it returns an error
+func (service *productService) ExportProducts(ctx context.Context, each func(Product) error) error {
+	return service.repository.ScanProducts(ctx, each)
+}
+
+// SearchProducts returns a page of the products matching filter, with the facets
+// of all of them. Filtering by a category also matches the products of its
+// subcategories.
+func (service *productService) SearchProducts(ctx context.Context, filter SearchFilter, skip uint64, take uint64) (*SearchResult, error) {
+	filter, err := validateSearchFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+	if len(filter.Categories) > 0 {
+		tree, err := service.categoryTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		filter.Categories = tree.withDescendants(filter.Categories)
+	}
 	if take > 100 || (skip == 0 && take == 0) {
 		take = 100
 	}
-	return service.repository.SearchProducts(ctx, query, skip, take)
+	return service.repository.SearchProducts(ctx, filter, skip, take)
+}
+
+// SuggestProducts returns up to size products whose names complete prefix, the
+// best completions first
+func (service *productService) SuggestProducts(ctx context.Context, prefix string, size uint32) ([]Suggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if prefix == "" {
+		return nil, fmt.Errorf("%w: prefix must not be empty", ErrInvalidSearch)
+	}
+	if size == 0 {
+		size = defaultSuggestions
+	}
+	if size > maxSuggestions {
+		size = maxSuggestions
+	}
+	return service.repository.SuggestProducts(ctx, prefix, int(size))
+}
+
+func (service *productService) UpdateProduct(ctx context.Context, id string, update ProductUpdate) (*Product, error) {
+	fields := map[string]interface{}{}
+	if update.Name != nil {
+		if strings.TrimSpace(*update.Name) == "" {
+			return nil, fmt.Errorf("%w: name must not be empty", ErrInvalidProduct)
+		}
+		fields["name"] = *update.Name
+	}
+	if update.Description != nil {
+		fields["description"] = *update.Description
+	}
+	if update.Price != nil {
+		price, err := validatePrice(*update.Price)
+		if err != nil {
+			return nil, err
+		}
+		fields["price"] = price
+	}
+	if update.WeightGrams != nil {
+		fields["weight_grams"] = *update.WeightGrams
+	}
+	if update.Categories != nil {
+		categories, err := service.checkCategories(ctx, *update.Categories)
+		if err != nil {
+			return nil, err
+		}
+		fields["categories"] = categories
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("%w: no fields to update", ErrInvalidProduct)
+	}
+	if err := service.repository.UpdateProduct(ctx, id, fields); err != nil {
+		return nil, err
+	}
+	return service.repository.GetProductByID(ctx, id)
+}
+
+func (service *productService) DeleteProduct(ctx context.Context, id string) error {
+	return service.repository.DeleteProduct(ctx, id)
+}
+
+func (service *productService) SetStock(ctx context.Context, id string, stock uint32) (*Product, error) {
+	if err := service.repository.SetStock(ctx, id, stock); err != nil {
+		return nil, err
+	}
+	return service.repository.GetProductByID(ctx, id)
+}
+
+func (service *productService) AdjustStock(ctx context.Context, id string, delta int32) (*Product, error) {
+	if _, err := service.repository.AdjustStock(ctx, id, delta); err != nil {
+		return nil, err
+	}
+	return service.repository.GetProductByID(ctx, id)
+}
+
+// ReserveStock takes the requested quantity of every product out of stock, or none
+// of them. Each product is decremented atomically; if any product is short, the
+// reservations already made are released again.
+func (service *productService) ReserveStock(ctx context.Context, items map[string]uint32) error {
+	if err := checkQuantities(items); err != nil {
+		return err
+	}
+	// Reserve in a stable order so concurrent reservations behave predictably
+	ids := make([]string, 0, len(items))
+	for id := range items {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	reserved := map[string]uint32{}
+	shortages := []StockShortage{}
+	for _, id := range ids {
+		available, err := service.repository.AdjustStock(ctx, id, -int32(items[id]))
+		if errors.Is(err, ErrInsufficientStock) {
+			shortages = append(shortages, StockShortage{ProductID: id, Requested: items[id], Available: available})
+			continue
+		}
+		if err != nil {
+			service.ReleaseStock(ctx, reserved)
+			return err
+		}
+		reserved[id] = items[id]
+	}
+	if len(shortages) > 0 {
+		service.ReleaseStock(ctx, reserved)
+		return &InsufficientStockError{Shortages: shortages}
+	}
+	return nil
+}
+
+func (service *productService) ReleaseStock(ctx context.Context, items map[string]uint32) error {
+	if err := checkQuantities(items); err != nil {
+		return err
+	}
+	var errs []error
+	for id, quantity := range items {
+		if _, err := service.repository.AdjustStock(ctx, id, int32(quantity)); err != nil {
+			errs = append(errs, fmt.Errorf("release stock for product %s: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkQuantities returns ErrInvalidQuantity unless every quantity fits the
+// int32 stock is adjusted by
+func checkQuantities(items map[string]uint32) error {
+	for _, quantity := range items {
+		if quantity == 0 || quantity > math.MaxInt32 {
+			return ErrInvalidQuantity
+		}
+	}
+	return nil
+}
+
+func (service *productService) PostCategory(ctx context.Context, slug string, name string, parent string) (*Category, error) {
+	category, err := validateCategory(Category{Slug: slug, Name: name, Parent: parent})
+	if err != nil {
+		return nil, err
+	}
+	if category.Parent != "" {
+		tree, err := service.categoryTree(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if err := tree.checkExist([]string{category.Parent}); err != nil {
+			return nil, err
+		}
+	}
+	if err := service.repository.PutCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// GetCategories returns every category; Parent links them into a tree
+func (service *productService) GetCategories(ctx context.Context) ([]Category, error) {
+	return service.repository.ListCategories(ctx)
+}
+
+// UpdateCategory renames and moves a category. A category cannot be moved under
+// itself or one of its descendants.
+func (service *productService) UpdateCategory(ctx context.Context, slug string, name string, parent string) (*Category, error) {
+	category, err := validateCategory(Category{Slug: slug, Name: name, Parent: parent})
+	if err != nil {
+		return nil, err
+	}
+	tree, err := service.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.checkExist([]string{category.Slug}); err != nil {
+		return nil, err
+	}
+	if category.Parent != "" {
+		if err := tree.checkExist([]string{category.Parent}); err != nil {
+			return nil, err
+		}
+		if tree.isDescendant(category.Parent, category.Slug) {
+			return nil, fmt.Errorf("%w: %s cannot be moved under its own subcategory %s", ErrInvalidCategory, category.Slug, category.Parent)
+		}
+	}
+	if err := service.repository.UpdateCategory(ctx, category); err != nil {
+		return nil, err
+	}
+	return &category, nil
+}
+
+// DeleteCategory deletes a category without subcategories or products
+func (service *productService) DeleteCategory(ctx context.Context, slug string) error {
+	tree, err := service.categoryTree(ctx)
+	if err != nil {
+		return err
+	}
+	if err := tree.checkExist([]string{slug}); err != nil {
+		return err
+	}
+	if len(tree.children[slug]) > 0 {
+		return fmt.Errorf("%w: %s has subcategories", ErrCategoryInUse, slug)
+	}
+	res, err := service.repository.SearchProducts(ctx, SearchFilter{Categories: []string{slug}, Sort: SortRelevance}, 0, 1)
+	if err != nil {
+		return err
+	}
+	if res.Total > 0 {
+		return fmt.Errorf("%w: %s has %d products", ErrCategoryInUse, slug, res.Total)
+	}
+	return service.repository.DeleteCategory(ctx, slug)
+}
+
+func (service *productService) categoryTree(ctx context.Context) (*categoryTree, error) {
+	categories, err := service.repository.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newCategoryTree(categories), nil
+}
+
+// checkCategories normalizes the category slugs of a product and checks the
+// categories exist
+func (service *productService) checkCategories(ctx context.Context, slugs []string) ([]string, error) {
+	slugs, err := normalizeCategories(slugs)
+	if err != nil || len(slugs) == 0 {
+		return slugs, err
+	}
+	tree, err := service.categoryTree(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := tree.checkExist(slugs); err != nil {
+		return nil, err
+	}
+	return slugs, nil
+}
+
+// validateImportedID rejects IDs that are neither empty nor a KSUID. Orders and
+// carts store product IDs as fixed-length KSUIDs.
+func validateImportedID(id string) error {
+	if id == "" {
+		return nil
+	}
+	if _, err := ksuid.Parse(id); err != nil {
+		return fmt.Errorf("%w: id must be a KSUID", ErrInvalidProduct)
+	}
+	return nil
+}
+
+// validatePrice normalizes the currency code of price and rejects negative prices
+func validatePrice(price money.Money) (money.Money, error) {
+	price, err := money.New(price.Amount, price.Currency)
+	if err != nil {
+		return money.Money{}, err
+	}
+	if price.IsNegative() {
+		return money.Money{}, fmt.Errorf("%w: price must not be negative", ErrInvalidProduct)
+	}
+	return price, nil
 }
@@ -0,0 +1,101 @@
+package product
+
+import (
+	"html"
+	"strings"
+)
+
+const (
+	// defaultSuggestions is the number of suggestions returned when none is asked
+	// for, maxSuggestions the most that can be asked for
+	defaultSuggestions = 10
+	maxSuggestions     = 20
+	// Highlights wrap the words of a name that match in these tags
+	highlightPreTag  = "<em>"
+	highlightPostTag = "</em>"
+)
+
+// Suggestion is a product whose name completes what the user typed so far
+type Suggestion struct {
+	ProductID string
+	Name      string
+	// Highlight is the HTML escaped name with its matching words wrapped in
+	// <em> tags
+	Highlight string
+}
+
+// fuzziness returns the number of typos tolerated in a term, like the AUTO
+// fuzziness of Elasticsearch: none up to 2 characters, one up to 5, then two
+func fuzziness(term string) int {
+	switch n := len([]rune(term)); {
+	case n <= 2:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// matchesTerm reports whether word contains term, or is within fuzziness typos
+// of it. Both must be lower case.
+func matchesTerm(word string, term string) bool {
+	return strings.Contains(word, term) || editDistance(word, term) <= fuzziness(term)
+}
+
+// editDistance returns the Levenshtein distance between a and b
+func editDistance(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// suggest matches a name against typed text: every term but the last must
+// fuzzily match a word of the name, and the last must start one. It returns the
+// name with the matching words highlighted, like the Elasticsearch highlighter.
+func suggest(name string, typed string) (string, bool) {
+	terms := strings.Fields(strings.ToLower(typed))
+	if len(terms) == 0 {
+		return "", false
+	}
+	words := strings.Fields(name)
+	matched := make([]bool, len(words))
+	for t, term := range terms {
+		found := false
+		for w, word := range words {
+			lower := strings.ToLower(word)
+			last := t == len(terms)-1
+			if (last && strings.HasPrefix(lower, term)) || (!last && matchesTerm(lower, term)) {
+				matched[w] = true
+				found = true
+			}
+		}
+		if !found {
+			return "", false
+		}
+	}
+	highlighted := []string{}
+	for w, word := range words {
+		word = html.EscapeString(word)
+		if matched[w] {
+			word = highlightPreTag + word + highlightPostTag
+		}
+		highlighted = append(highlighted, word)
+	}
+	return strings.Join(highlighted, " "), true
+}